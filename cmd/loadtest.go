@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/cache/market"
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/libs/queue"
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/risk"
+	"github.com/anvh2/futures-trading/internal/services/binance/simulated"
+	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/anvh2/futures-trading/internal/worker"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var (
+	loadtestSymbols  int
+	loadtestRate     int
+	loadtestDuration time.Duration
+	loadtestWorkers  int
+)
+
+const loadtestConsumerId = "loadtest-executor"
+
+// signalEvent carries a synthetic oscillator through the queue alongside
+// the time it was generated, so the consumer can measure end-to-end
+// latency. Unlike messages that arrive off the wire (see orderer.open),
+// it's handed to Queue.Push and read back by direct type assertion
+// instead of round-tripping through JSON, since producer and consumer
+// are the same process here.
+type signalEvent struct {
+	oscillator *models.Oscillator
+	sentAt     time.Time
+}
+
+// loadtestCmd drives synthetic signals through the same decision ->
+// risk -> executor shape as analyzer.process -> orderer.open, in-process
+// and against services/binance/simulated, so throughput, latency and
+// queue depth can be sized for 300+ symbols without a live Binance
+// account or real market data for every symbol.
+var loadtestCmd = &cobra.Command{
+	Use:   "loadtest",
+	Short: "Benchmark decision pipeline throughput against the simulated exchange",
+	Long:  "Pumps synthetic signals through the decision, risk and (simulated) execution pipeline at a configured rate, then reports throughput, end-to-end latency percentiles, and queue depth.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if loadtestSymbols <= 0 {
+			return errors.New("loadtest: --symbols must be positive")
+		}
+
+		if loadtestRate <= 0 {
+			return errors.New("loadtest: --rate must be positive")
+		}
+
+		log, err := logger.New("stdout", true)
+		if err != nil {
+			return err
+		}
+
+		cfg := settings.NewDefaultSettings()
+		tradingInterval := cfg.IntervalFor(cfg.TradingStrategy)
+
+		mkt := market.NewMarket(500)
+		symbols := make([]string, loadtestSymbols)
+
+		for i := range symbols {
+			symbol := fmt.Sprintf("SYN%04dUSDT", i)
+			symbols[i] = symbol
+
+			_ = mkt.CreateSummary(symbol).CreateCandle(tradingInterval, &models.Candlestick{
+				OpenTime:  time.Now().UnixMilli(),
+				CloseTime: time.Now().UnixMilli(),
+				Close:     fmt.Sprintf("%.2f", 100+float64(i%50)),
+			})
+		}
+
+		exchange := simulated.New(log, mkt, cfg)
+
+		q := queue.New()
+		defer q.Close()
+
+		var (
+			pushed, executed, failed int64
+			latencies                []time.Duration
+			latenciesMux             sync.Mutex
+			depthSamples             []int64
+		)
+
+		pool, err := worker.New(log, &worker.PoolConfig{NumProcess: int32(loadtestWorkers)})
+		if err != nil {
+			return err
+		}
+
+		pool.WithProcess(func(ctx context.Context, data interface{}) error {
+			evt, ok := data.(*signalEvent)
+			if !ok {
+				return errors.New("loadtest: unexpected message type")
+			}
+
+			side, positionSide := futures.SideTypeBuy, futures.PositionSideTypeLong
+			if helpers.ResolvePositionSide(evt.oscillator.GetRSI(tradingInterval)) == "SHORT" {
+				side, positionSide = futures.SideTypeSell, futures.PositionSideTypeShort
+			}
+
+			order := &models.Order{
+				Symbol:       evt.oscillator.Symbol,
+				Side:         side,
+				PositionSide: positionSide,
+				OrderType:    futures.OrderTypeMarket,
+				Quantity:     "0.01",
+			}
+
+			_, execErr := exchange.OpenOrders(ctx, []*models.Order{order})
+
+			latenciesMux.Lock()
+			latencies = append(latencies, time.Since(evt.sentAt))
+			latenciesMux.Unlock()
+
+			if execErr != nil {
+				atomic.AddInt64(&failed, 1)
+				return execErr
+			}
+
+			atomic.AddInt64(&executed, 1)
+			return nil
+		})
+
+		if err := pool.Start(); err != nil {
+			return err
+		}
+
+		drain := make(chan struct{})
+
+		go func() {
+			ticker := time.NewTicker(5 * time.Millisecond)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					depthSamples = append(depthSamples, q.Depth(loadtestConsumerId))
+
+					for {
+						msg, err := q.Peak(loadtestConsumerId)
+						if err != nil {
+							break
+						}
+						pool.SendJob(context.Background(), msg.Data)
+						q.Commit(loadtestConsumerId, msg.Offset)
+					}
+
+				case <-drain:
+					return
+				}
+			}
+		}()
+
+		fmt.Printf("loadtest: %d symbols, %d signals/sec, for %s\n", loadtestSymbols, loadtestRate, loadtestDuration)
+
+		ticker := time.NewTicker(time.Second / time.Duration(loadtestRate))
+		deadline := time.After(loadtestDuration)
+
+	producing:
+		for idx := 0; ; idx++ {
+			select {
+			case <-ticker.C:
+				symbol := symbols[idx%len(symbols)]
+
+				rsi := 80.0
+				if idx%2 == 0 {
+					rsi = 20.0
+				}
+
+				oscillator := &models.Oscillator{
+					Symbol: symbol,
+					Stoch: map[string]*models.Stoch{
+						tradingInterval: {RSI: rsi, K: 50, D: 50},
+					},
+				}
+				oscillator.Confidence = risk.AdjustConfidence(1, nil)
+
+				evt := &signalEvent{oscillator: oscillator, sentAt: time.Now()}
+				if err := q.Push(evt, time.Minute); err != nil {
+					log.Error("[LoadTest] failed to push signal", zap.Error(err))
+					continue
+				}
+
+				atomic.AddInt64(&pushed, 1)
+
+			case <-deadline:
+				ticker.Stop()
+				break producing
+			}
+		}
+
+		// give the consumer a moment to drain whatever's still queued.
+		time.Sleep(2 * time.Second)
+		close(drain)
+		pool.Stop()
+
+		reportLoadtest(atomic.LoadInt64(&pushed), atomic.LoadInt64(&executed), atomic.LoadInt64(&failed), latencies, depthSamples, loadtestDuration)
+		return nil
+	},
+}
+
+// reportLoadtest prints throughput, end-to-end latency percentiles and
+// queue depth for one loadtestCmd run.
+func reportLoadtest(pushed, executed, failed int64, latencies []time.Duration, depthSamples []int64, duration time.Duration) {
+	avgDepth, maxDepth := loadtestDepthStats(depthSamples)
+
+	fmt.Printf("pushed=%d executed=%d failed=%d\n", pushed, executed, failed)
+	fmt.Printf("throughput: %.1f signals/sec\n", float64(pushed)/duration.Seconds())
+	fmt.Printf("latency: p50=%s p95=%s p99=%s\n",
+		loadtestPercentile(latencies, 0.50), loadtestPercentile(latencies, 0.95), loadtestPercentile(latencies, 0.99))
+	fmt.Printf("queue depth: avg=%d max=%d\n", avgDepth, maxDepth)
+}
+
+// loadtestPercentile returns the p-th percentile (0-1) of samples.
+func loadtestPercentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	idx := int(float64(len(sorted)-1) * p)
+	return sorted[idx]
+}
+
+// loadtestDepthStats returns the average and maximum of samples.
+func loadtestDepthStats(samples []int64) (avg, max int64) {
+	if len(samples) == 0 {
+		return 0, 0
+	}
+
+	var sum int64
+	for _, s := range samples {
+		sum += s
+		if s > max {
+			max = s
+		}
+	}
+
+	return sum / int64(len(samples)), max
+}
+
+func init() {
+	loadtestCmd.Flags().IntVar(&loadtestSymbols, "symbols", 300, "number of distinct synthetic symbols to simulate")
+	loadtestCmd.Flags().IntVar(&loadtestRate, "rate", 100, "synthetic signals pushed per second")
+	loadtestCmd.Flags().DurationVar(&loadtestDuration, "duration", 10*time.Second, "how long to pump signals before reporting")
+	loadtestCmd.Flags().IntVar(&loadtestWorkers, "workers", 8, "executor worker pool size")
+
+	RootCmd.AddCommand(loadtestCmd)
+}
@@ -65,6 +65,8 @@ func initConfig() {
 		log.Fatalf("Error loading .env file")
 	}
 
+	viper.SetDefault("trading.log_json", true)
+
 	fmt.Println("Using env file:", envFile)
 	fmt.Println("Using config file:", viper.ConfigFileUsed())
 }
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/anvh2/futures-trading/internal/libs/simpledb"
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/anvh2/futures-trading/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statePath string
+	stateFile string
+
+	backupRetention                                                                        int
+	backupS3Endpoint, backupS3Bucket, backupS3Region, backupS3AccessKey, backupS3SecretKey string
+)
+
+// stateCmd groups state snapshot/restore subcommands.
+var stateCmd = &cobra.Command{
+	Use:   "state",
+	Short: "Manage persisted StateManager state",
+	Long:  "Export or import StateManager state and settings, to move a bot between hosts or roll back after a bad deployment",
+}
+
+// snapshot bundles TradingState and Settings together so a single file
+// captures everything needed to restore a bot.
+type snapshot struct {
+	State    *state.TradingState `json:"state"`
+	Settings *settings.Settings  `json:"settings"`
+}
+
+var stateExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Dump StateManager state and settings to a file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log, err := logger.New("stdout", true)
+		if err != nil {
+			return err
+		}
+
+		manager := state.New(log, statePath)
+		if err := manager.Load(); err != nil {
+			return err
+		}
+
+		snap := &snapshot{
+			State:    manager.GetState(),
+			Settings: settings.DefaultSettings,
+		}
+
+		data, err := json.MarshalIndent(snap, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(stateFile, data, 0644); err != nil {
+			return err
+		}
+
+		fmt.Println("exported state to", stateFile)
+		return nil
+	},
+}
+
+var stateImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Restore StateManager state and settings from a file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(stateFile)
+		if err != nil {
+			return err
+		}
+
+		snap := &snapshot{}
+		if err := json.Unmarshal(data, snap); err != nil {
+			return err
+		}
+
+		log, err := logger.New("stdout", true)
+		if err != nil {
+			return err
+		}
+
+		manager := state.New(log, statePath)
+		if err := manager.Restore(snap.State); err != nil {
+			return err
+		}
+
+		if err := manager.Save(); err != nil {
+			return err
+		}
+
+		fmt.Println("imported state from", stateFile)
+		return nil
+	},
+}
+
+var stateBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Copy the StateManager backing file to a timestamped, rotated local backup",
+	Long:  "Copy the StateManager backing file to a timestamped, rotated local backup, optionally also uploading it to an S3-compatible bucket. Intended to run on a schedule (e.g. a cron alongside the server), since the server itself never calls this.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log, err := logger.New("stdout", true)
+		if err != nil {
+			return err
+		}
+
+		manager := state.New(log, statePath)
+		if err := manager.Load(); err != nil {
+			return err
+		}
+
+		cfg := &simpledb.BackupConfig{LocalRetention: backupRetention}
+		if backupS3Endpoint != "" {
+			cfg.Remote = simpledb.NewS3Backend(backupS3Endpoint, backupS3Bucket, backupS3Region, backupS3AccessKey, backupS3SecretKey)
+		}
+
+		if err := manager.Backup(cfg); err != nil {
+			return err
+		}
+
+		fmt.Println("backed up state at", statePath)
+		return nil
+	},
+}
+
+func init() {
+	stateCmd.PersistentFlags().StringVar(&statePath, "state-path", "state.json", "StateManager backing file")
+	stateExportCmd.Flags().StringVar(&stateFile, "file", "state.snapshot.json", "output snapshot file")
+	stateImportCmd.Flags().StringVar(&stateFile, "file", "state.snapshot.json", "input snapshot file")
+
+	stateBackupCmd.Flags().IntVar(&backupRetention, "retain", 10, "number of rotated local backups to keep")
+	stateBackupCmd.Flags().StringVar(&backupS3Endpoint, "s3-endpoint", "", "optional S3-compatible endpoint to also upload the backup to, e.g. https://s3.us-east-1.amazonaws.com")
+	stateBackupCmd.Flags().StringVar(&backupS3Bucket, "s3-bucket", "", "S3 bucket to upload to, required with --s3-endpoint")
+	stateBackupCmd.Flags().StringVar(&backupS3Region, "s3-region", "", "S3 region, required with --s3-endpoint")
+	stateBackupCmd.Flags().StringVar(&backupS3AccessKey, "s3-access-key", "", "S3 access key, required with --s3-endpoint")
+	stateBackupCmd.Flags().StringVar(&backupS3SecretKey, "s3-secret-key", "", "S3 secret key, required with --s3-endpoint")
+
+	stateCmd.AddCommand(stateExportCmd)
+	stateCmd.AddCommand(stateImportCmd)
+	stateCmd.AddCommand(stateBackupCmd)
+	RootCmd.AddCommand(stateCmd)
+}
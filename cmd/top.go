@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	topAddr     string
+	topInterval time.Duration
+)
+
+// topCmd polls a running server's admin HTTP API and renders a live
+// terminal dashboard, for operators without the web dashboard.
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Live terminal dashboard of positions, PnL, queue depth and breakers",
+	Long:  "Polls a running server's /v1/status admin endpoint and renders positions, per-symbol PnL, decision queue depth, and active safety breaker states, refreshing every --interval.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ticker := time.NewTicker(topInterval)
+		defer ticker.Stop()
+
+		if err := renderTop(topAddr); err != nil {
+			return err
+		}
+
+		for range ticker.C {
+			if err := renderTop(topAddr); err != nil {
+				fmt.Println("top: failed to refresh:", err)
+			}
+		}
+
+		return nil
+	},
+}
+
+// topPosition mirrors server.StatusPosition, kept as its own type so
+// cmd doesn't depend on internal/server.
+type topPosition struct {
+	Symbol     string `json:"symbol"`
+	Side       string `json:"side"`
+	EntryPrice string `json:"entry_price"`
+	StopPrice  string `json:"stop_price"`
+}
+
+// topSymbol mirrors the models.SymbolStats fields this command
+// renders.
+type topSymbol struct {
+	Symbol   string  `json:"symbol"`
+	Trades   int     `json:"trades"`
+	WinRate  float64 `json:"win_rate"`
+	TotalPNL float64 `json:"total_pnl"`
+}
+
+// topBreaker mirrors server.StatusBreaker.
+type topBreaker struct {
+	Symbol      string `json:"symbol"`
+	Rule        string `json:"rule"`
+	Message     string `json:"message"`
+	Occurrences int    `json:"occurrences"`
+}
+
+// topStatus mirrors server.Status, the payload /v1/status returns.
+type topStatus struct {
+	TradingEnabled bool           `json:"trading_enabled"`
+	QueueDepth     int64          `json:"queue_depth"`
+	Positions      []*topPosition `json:"positions"`
+	Symbols        []*topSymbol   `json:"symbols"`
+	Breakers       []*topBreaker  `json:"breakers"`
+}
+
+// fetchTopStatus fetches and decodes addr's /v1/status.
+func fetchTopStatus(addr string) (*topStatus, error) {
+	resp, err := http.Get(addr + "/v1/status")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("top: %s returned %s", addr, resp.Status)
+	}
+
+	status := &topStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// renderTop fetches addr's current status and redraws the dashboard,
+// clearing the terminal first so each refresh replaces the last
+// instead of scrolling.
+func renderTop(addr string) error {
+	status, err := fetchTopStatus(addr)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print("\033[H\033[2J")
+	fmt.Printf("futures-trading top  %s  trading_enabled=%v  queue_depth=%d\n\n", time.Now().Format(time.TimeOnly), status.TradingEnabled, status.QueueDepth)
+
+	fmt.Println("POSITIONS")
+	if len(status.Positions) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, position := range status.Positions {
+		fmt.Printf("  %-12s %-5s entry=%-10s stop=%-10s\n", position.Symbol, position.Side, position.EntryPrice, position.StopPrice)
+	}
+
+	symbols := append([]*topSymbol{}, status.Symbols...)
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].TotalPNL < symbols[j].TotalPNL })
+
+	fmt.Println("\nSYMBOL PNL")
+	if len(symbols) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, symbol := range symbols {
+		fmt.Printf("  %-12s trades=%-4d win_rate=%-6.1f%% pnl=%.4f\n", symbol.Symbol, symbol.Trades, symbol.WinRate*100, symbol.TotalPNL)
+	}
+
+	fmt.Println("\nBREAKERS")
+	if len(status.Breakers) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, breaker := range status.Breakers {
+		fmt.Printf("  %-12s %-28s x%-3d %s\n", breaker.Symbol, breaker.Rule, breaker.Occurrences, breaker.Message)
+	}
+
+	return nil
+}
+
+func init() {
+	topCmd.Flags().StringVar(&topAddr, "addr", "http://localhost:8080", "base URL of the running server's admin HTTP API")
+	topCmd.Flags().DurationVar(&topInterval, "interval", 3*time.Second, "dashboard refresh interval")
+
+	RootCmd.AddCommand(topCmd)
+}
@@ -0,0 +1,82 @@
+// Package broadcast implements fan-out pub/sub: unlike
+// internal/channel's single-consumer-per-name queues, every Subscribe
+// call gets its own channel that receives a copy of each message
+// published afterward, so multiple independent readers (e.g. several
+// concurrent market data subscribers) can consume the same feed
+// without competing for messages.
+package broadcast
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer bounds how many unread messages a slow subscriber
+// can fall behind by before Publish starts dropping for it, so one
+// stalled reader can't block or unbound-queue against the publisher.
+const subscriberBuffer = 64
+
+// Hub fans messages published on a topic out to every subscriber
+// currently listening on it.
+type Hub struct {
+	mux         sync.Mutex
+	subscribers map[string]map[chan interface{}]struct{}
+}
+
+// New returns an empty Hub.
+func New() *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[chan interface{}]struct{}),
+	}
+}
+
+// Subscribe returns a channel that receives a copy of every message
+// Publish sends on topic, until ctx is done, at which point the
+// channel is closed and unregistered.
+func (h *Hub) Subscribe(ctx context.Context, topic string) <-chan interface{} {
+	ch := make(chan interface{}, subscriberBuffer)
+
+	h.mux.Lock()
+	if h.subscribers[topic] == nil {
+		h.subscribers[topic] = make(map[chan interface{}]struct{})
+	}
+	h.subscribers[topic][ch] = struct{}{}
+	h.mux.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		h.mux.Lock()
+		delete(h.subscribers[topic], ch)
+		if len(h.subscribers[topic]) == 0 {
+			delete(h.subscribers, topic)
+		}
+		h.mux.Unlock()
+
+		close(ch)
+	}()
+
+	return ch
+}
+
+// MarketTopic is the topic convention producers (crawler, analyzer)
+// and subscribers use to key candle/indicator updates by symbol and
+// interval, e.g. "BTCUSDT:15m".
+func MarketTopic(symbol, interval string) string {
+	return symbol + ":" + interval
+}
+
+// Publish sends message to every subscriber currently listening on
+// topic. A subscriber whose buffer is full drops the message rather
+// than blocking the publisher.
+func (h *Hub) Publish(topic string, message interface{}) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	for ch := range h.subscribers[topic] {
+		select {
+		case ch <- message:
+		default:
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPublishFansOutToEverySubscriber(t *testing.T) {
+	hub := New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := hub.Subscribe(ctx, "BTCUSDT:1m")
+	b := hub.Subscribe(ctx, "BTCUSDT:1m")
+
+	hub.Publish("BTCUSDT:1m", "tick")
+
+	for _, ch := range []<-chan interface{}{a, b} {
+		select {
+		case got := <-ch:
+			if got != "tick" {
+				t.Errorf("got %v, want tick", got)
+			}
+		case <-time.After(time.Second):
+			t.Error("subscriber never received the published message")
+		}
+	}
+}
+
+func TestPublishIgnoresOtherTopics(t *testing.T) {
+	hub := New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := hub.Subscribe(ctx, "BTCUSDT:1m")
+	hub.Publish("ETHUSDT:1m", "tick")
+
+	select {
+	case got := <-ch:
+		t.Errorf("subscriber on a different topic should not receive anything, got %v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscribeClosesChannelWhenContextDone(t *testing.T) {
+	hub := New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := hub.Subscribe(ctx, "BTCUSDT:1m")
+
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("channel should be closed, not deliver a value")
+		}
+	case <-time.After(time.Second):
+		t.Error("channel was never closed after its context was done")
+	}
+}
+
+func TestPublishDropsForFullSubscriberBuffer(t *testing.T) {
+	hub := New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := hub.Subscribe(ctx, "BTCUSDT:1m")
+
+	for i := 0; i < subscriberBuffer+1; i++ {
+		hub.Publish("BTCUSDT:1m", i)
+	}
+
+	if got := len(ch); got != subscriberBuffer {
+		t.Errorf("got %v buffered messages, want %v (newest message dropped)", got, subscriberBuffer)
+	}
+}
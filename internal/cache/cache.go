@@ -17,6 +17,10 @@ type Exchange interface {
 	Set(symbols []*exchange.Symbol)
 	Get(symbol string) (*exchange.Symbol, error)
 	Symbols() []string
+	SetMaintenance(maintenance bool)
+	Maintenance() bool
+	SetNewsFlags(flags map[string]string)
+	NewsFlag(symbol string) (string, bool)
 }
 
 //go:generate moq -pkg cachemock -out ./mocks/basic_mock.go . Basic
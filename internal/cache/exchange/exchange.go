@@ -8,10 +8,12 @@ import (
 )
 
 type Exchange struct {
-	logger   *logger.Logger
-	mux      *sync.RWMutex
-	symbols  []string
-	internal map[string]*Symbol
+	logger      *logger.Logger
+	mux         *sync.RWMutex
+	symbols     []string
+	internal    map[string]*Symbol
+	maintenance bool
+	newsFlags   map[string]string
 }
 
 func New(logger *logger.Logger) *Exchange {
@@ -22,16 +24,23 @@ func New(logger *logger.Logger) *Exchange {
 	}
 }
 
+// Set replaces the cached symbol set, dropping any symbol (e.g. one
+// delisted since the last Set) that isn't present in the new list, so
+// Get doesn't keep serving stale data for a contract that no longer
+// exists on the exchange.
 func (c *Exchange) Set(symbols []*Symbol) {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
 	c.symbols = make([]string, len(symbols))
+	fresh := make(map[string]*Symbol, len(symbols))
 
 	for idx, symbol := range symbols {
 		c.symbols[idx] = symbol.Symbol
-		c.internal[symbol.Symbol] = symbol
+		fresh[symbol.Symbol] = symbol
 	}
+
+	c.internal = fresh
 }
 
 func (c *Exchange) Get(symbol string) (*Symbol, error) {
@@ -49,3 +58,44 @@ func (c *Exchange) Get(symbol string) (*Symbol, error) {
 func (c *Exchange) Symbols() []string {
 	return c.symbols
 }
+
+// SetMaintenance records whether Binance is currently in an
+// exchange-wide maintenance window, as reported by
+// binance.Binance.GetSystemStatus, see safety.TradingStatusRule.
+func (c *Exchange) SetMaintenance(maintenance bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.maintenance = maintenance
+}
+
+// Maintenance reports whether Binance is currently in an
+// exchange-wide maintenance window, as of the last SetMaintenance call.
+func (c *Exchange) Maintenance() bool {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	return c.maintenance
+}
+
+// SetNewsFlags replaces the set of symbols currently flagged by a
+// keyword hit in the news feed with flags, keyed by symbol, value the
+// matched headline, see safety.NewsKillSwitchRule. A symbol missing
+// from flags is no longer considered flagged, so a headline that
+// scrolls off the feed stops pausing that symbol on the next poll.
+func (c *Exchange) SetNewsFlags(flags map[string]string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.newsFlags = flags
+}
+
+// NewsFlag reports the headline that flagged symbol, if any, as of the
+// last SetNewsFlags call.
+func (c *Exchange) NewsFlag(symbol string) (string, bool) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	headline, ok := c.newsFlags[symbol]
+	return headline, ok
+}
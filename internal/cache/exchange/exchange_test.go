@@ -0,0 +1,27 @@
+package exchange
+
+import (
+	"testing"
+
+	"github.com/anvh2/futures-trading/internal/logger"
+)
+
+func TestSetDropsDelistedSymbols(t *testing.T) {
+	cache := New(logger.NewDev())
+
+	cache.Set([]*Symbol{{Symbol: "BTCUSDT"}, {Symbol: "ETHUSDT"}})
+
+	if _, err := cache.Get("ETHUSDT"); err != nil {
+		t.Fatalf("expected ETHUSDT to be cached: %v", err)
+	}
+
+	cache.Set([]*Symbol{{Symbol: "BTCUSDT"}})
+
+	if _, err := cache.Get("ETHUSDT"); err == nil {
+		t.Fatal("expected ETHUSDT to be dropped after being delisted")
+	}
+
+	if _, err := cache.Get("BTCUSDT"); err != nil {
+		t.Fatalf("expected BTCUSDT to still be cached: %v", err)
+	}
+}
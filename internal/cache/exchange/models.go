@@ -1,6 +1,8 @@
 package exchange
 
 import (
+	"time"
+
 	"github.com/adshao/go-binance/v2/futures"
 	"github.com/anvh2/futures-trading/internal/cache/errors"
 	"github.com/mitchellh/mapstructure"
@@ -39,6 +41,47 @@ type Symbol struct {
 	Filters     *Filters `json:"filters,omitempty"`
 	MarginAsset string   `json:"marginAsset,omitempty"`
 	BaseAsset   string   `json:"baseAsset,omitempty"`
+	QuoteAsset  string   `json:"quoteAsset,omitempty"`
+	// OnboardDate is the millisecond timestamp the symbol was listed,
+	// as reported by exchange info, used to gate new-listing risk rules.
+	OnboardDate int64 `json:"onboardDate,omitempty"`
+	// UnderlyingType is the exchange's asset classification for the
+	// symbol (e.g. "COIN", "INDEX").
+	UnderlyingType string `json:"underlyingType,omitempty"`
+	// Status is the exchange-reported trading status for the symbol
+	// (e.g. "TRADING", "BREAK", "SETTLING"), used to gate trading on
+	// halted symbols, see Halted and safety.TradingStatusRule.
+	Status string `json:"status,omitempty"`
+}
+
+// Halted reports whether the exchange is currently not accepting
+// trades for s, as of the last exchange info refresh.
+func (s *Symbol) Halted() bool {
+	return s.Status != "" && s.Status != "TRADING"
+}
+
+// BaseQuote returns s's base and quote assets, as reported by exchange
+// info, instead of a caller guessing from the symbol string: naively
+// slicing a fixed prefix (e.g. symbol[:3]) breaks for symbols like
+// 1000PEPEUSDT, whose base asset carries a leading quantity
+// multiplier, or RNDRUSDT, whose base asset is longer than three
+// characters.
+func (s *Symbol) BaseQuote() (base, quote string) {
+	return s.BaseAsset, s.QuoteAsset
+}
+
+// DaysListed returns how many days have elapsed since OnboardDate, as of now.
+func (s *Symbol) DaysListed(now time.Time) int {
+	if s.OnboardDate <= 0 {
+		return 0
+	}
+
+	onboarded := time.UnixMilli(s.OnboardDate)
+	if now.Before(onboarded) {
+		return 0
+	}
+
+	return int(now.Sub(onboarded).Hours() / 24)
 }
 
 func (s *Symbol) GetPriceFilter() (*Filter, error) {
@@ -50,6 +93,15 @@ func (s *Symbol) GetPriceFilter() (*Filter, error) {
 	return nil, errors.ErrorFilterNotFound
 }
 
+func (s *Symbol) GetPercentPriceFilter() (*Filter, error) {
+	for _, filter := range *s.Filters {
+		if filter.FilterType == futures.SymbolFilterTypePercentPrice {
+			return filter, nil
+		}
+	}
+	return nil, errors.ErrorFilterNotFound
+}
+
 func (s *Symbol) GetLotSizeFilter() (*Filter, error) {
 	for _, filter := range *s.Filters {
 		if filter.FilterType == futures.SymbolFilterTypeLotSize {
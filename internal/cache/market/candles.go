@@ -0,0 +1,153 @@
+package market
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+// Candles is a fixed-capacity ring buffer holding one symbol/interval's
+// candles in struct-of-arrays layout: parallel int64/float64 slices
+// instead of a slice of *models.Candlestick pointers with string
+// prices. At 300 symbols x 6 intervals x a few hundred candles,
+// circular.Cache's map[int32]interface{} of boxed structs with
+// separately-heap-allocated price strings is both memory-heavy and
+// GC-hostile; these are plain slices of primitives.
+type Candles struct {
+	mutex *sync.RWMutex
+	idx   int32
+	len   int32
+	size  int32
+
+	openTime  []int64
+	closeTime []int64
+	open      []float64
+	high      []float64
+	low       []float64
+	close     []float64
+	volume    []float64
+}
+
+// NewCandles returns an empty ring buffer holding up to size candles.
+func NewCandles(size int32) *Candles {
+	return &Candles{
+		mutex:     &sync.RWMutex{},
+		size:      size,
+		openTime:  make([]int64, size),
+		closeTime: make([]int64, size),
+		open:      make([]float64, size),
+		high:      make([]float64, size),
+		low:       make([]float64, size),
+		close:     make([]float64, size),
+		volume:    make([]float64, size),
+	}
+}
+
+// Insert appends candle, overwriting the oldest slot once size is
+// reached, and returns the slot it was written to.
+func (c *Candles) Insert(candle *models.Candlestick) int32 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.idx >= c.size {
+		c.idx -= c.size
+	}
+
+	c.set(c.idx, candle)
+	c.idx++
+
+	if c.len < c.size {
+		c.len++
+	}
+
+	return c.idx - 1
+}
+
+// Update overwrites the candle at idx in place.
+func (c *Candles) Update(idx int32, candle *models.Candlestick) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.set(idx, candle)
+}
+
+func (c *Candles) set(idx int32, candle *models.Candlestick) {
+	c.openTime[idx] = candle.OpenTime
+	c.closeTime[idx] = candle.CloseTime
+	c.open[idx] = candle.OpenFloat()
+	c.high[idx] = candle.HighFloat()
+	c.low[idx] = candle.LowFloat()
+	c.close[idx] = candle.CloseFloat()
+	c.volume[idx] = candle.VolumeFloat()
+}
+
+// get reconstructs the *models.Candlestick at idx, so this ring buffer
+// drops in wherever circular.Cache did without every caller having to
+// switch to the raw float slices.
+func (c *Candles) get(idx int32) *models.Candlestick {
+	return &models.Candlestick{
+		OpenTime:  c.openTime[idx],
+		CloseTime: c.closeTime[idx],
+		Open:      strconv.FormatFloat(c.open[idx], 'f', -1, 64),
+		High:      strconv.FormatFloat(c.high[idx], 'f', -1, 64),
+		Low:       strconv.FormatFloat(c.low[idx], 'f', -1, 64),
+		Close:     strconv.FormatFloat(c.close[idx], 'f', -1, 64),
+		Volume:    strconv.FormatFloat(c.volume[idx], 'f', -1, 64),
+	}
+}
+
+// Read returns every stored candle in insertion-slot order (slot 0
+// first), not chronological order; use Sorted for that.
+func (c *Candles) Read() []interface{} {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	data := make([]interface{}, c.len)
+	for i := int32(0); i < c.len; i++ {
+		data[i] = c.get(i)
+	}
+
+	return data
+}
+
+// Tail returns the most recently inserted candle and its slot.
+func (c *Candles) Tail() (interface{}, int32) {
+	if c == nil {
+		return nil, -1
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var idx int32
+	if c.idx == 0 {
+		idx = c.size - 1
+	} else {
+		idx = c.idx - 1
+	}
+
+	return c.get(idx), idx
+}
+
+// Sorted returns every stored candle in chronological order, oldest
+// first.
+func (c *Candles) Sorted() []interface{} {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	idx := 0
+	data := make([]interface{}, c.len)
+
+	for i := c.idx; i < c.len; i++ {
+		data[idx] = c.get(i)
+		idx++
+	}
+
+	for i := int32(0); i < c.idx; i++ {
+		data[idx] = c.get(i)
+		idx++
+	}
+
+	return data
+}
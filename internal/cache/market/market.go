@@ -3,7 +3,6 @@ package market
 import (
 	"sync"
 
-	"github.com/anvh2/futures-trading/internal/cache/circular"
 	"github.com/anvh2/futures-trading/internal/cache/errors"
 )
 
@@ -56,7 +55,7 @@ func (c *Market) UpdateSummary(symbol string) *CandleSummary {
 	return c.cache[symbol]
 }
 
-func (c *Market) Candles(symbol, interval string) *circular.Cache {
+func (c *Market) Candles(symbol, interval string) *Candles {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -68,7 +67,7 @@ func (c *Market) Candles(symbol, interval string) *circular.Cache {
 	summary := c.cache[symbol].cache[interval]
 	if summary == nil {
 		summary = &SummaryData{
-			Candles: circular.New(c.limit),
+			Candles: NewCandles(c.limit),
 		}
 	}
 
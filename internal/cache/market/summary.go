@@ -4,15 +4,14 @@ import (
 	"sync"
 	"time"
 
-	"github.com/anvh2/futures-trading/internal/cache/circular"
 	"github.com/anvh2/futures-trading/internal/cache/errors"
 	"github.com/anvh2/futures-trading/internal/models"
 )
 
 type SummaryData struct {
-	Candles    *circular.Cache `json:"candles,omitempty"`
-	CreateTime int64           `json:"create_time,omitempty"`
-	UpdateTime int64           `json:"update_time,omitempty"`
+	Candles    *Candles `json:"candles,omitempty"`
+	CreateTime int64    `json:"create_time,omitempty"`
+	UpdateTime int64    `json:"update_time,omitempty"`
 }
 
 type CandleSummary struct {
@@ -31,7 +30,7 @@ func (m *CandleSummary) Init(symbol string, limit int32) *CandleSummary {
 	}
 }
 
-func (m *CandleSummary) Candles(interval string) (*circular.Cache, error) {
+func (m *CandleSummary) Candles(interval string) (*Candles, error) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
@@ -48,7 +47,7 @@ func (m *CandleSummary) CreateCandle(interval string, candle *models.Candlestick
 
 	if m.cache[interval] == nil {
 		m.cache[interval] = &SummaryData{
-			Candles:    circular.New(m.limit),
+			Candles:    NewCandles(m.limit),
 			CreateTime: time.Now().UnixMilli(),
 			UpdateTime: time.Now().UnixMilli(),
 		}
@@ -65,7 +64,7 @@ func (m *CandleSummary) UpdateCandle(interval string, candleId int32, candle *mo
 
 	if m.cache[interval] == nil {
 		m.cache[interval] = &SummaryData{
-			Candles:    circular.New(m.limit),
+			Candles:    NewCandles(m.limit),
 			UpdateTime: time.Now().UnixMilli(),
 		}
 	}
@@ -22,9 +22,21 @@ var _ cache.Exchange = &ExchangeMock{}
 //			GetFunc: func(symbol string) (*exchange.Symbol, error) {
 //				panic("mock out the Get method")
 //			},
+//			MaintenanceFunc: func() bool {
+//				panic("mock out the Maintenance method")
+//			},
+//			NewsFlagFunc: func(symbol string) (string, bool) {
+//				panic("mock out the NewsFlag method")
+//			},
 //			SetFunc: func(symbols []*exchange.Symbol)  {
 //				panic("mock out the Set method")
 //			},
+//			SetMaintenanceFunc: func(maintenance bool)  {
+//				panic("mock out the SetMaintenance method")
+//			},
+//			SetNewsFlagsFunc: func(flags map[string]string)  {
+//				panic("mock out the SetNewsFlags method")
+//			},
 //			SymbolsFunc: func() []string {
 //				panic("mock out the Symbols method")
 //			},
@@ -38,9 +50,21 @@ type ExchangeMock struct {
 	// GetFunc mocks the Get method.
 	GetFunc func(symbol string) (*exchange.Symbol, error)
 
+	// MaintenanceFunc mocks the Maintenance method.
+	MaintenanceFunc func() bool
+
+	// NewsFlagFunc mocks the NewsFlag method.
+	NewsFlagFunc func(symbol string) (string, bool)
+
 	// SetFunc mocks the Set method.
 	SetFunc func(symbols []*exchange.Symbol)
 
+	// SetMaintenanceFunc mocks the SetMaintenance method.
+	SetMaintenanceFunc func(maintenance bool)
+
+	// SetNewsFlagsFunc mocks the SetNewsFlags method.
+	SetNewsFlagsFunc func(flags map[string]string)
+
 	// SymbolsFunc mocks the Symbols method.
 	SymbolsFunc func() []string
 
@@ -51,18 +75,40 @@ type ExchangeMock struct {
 			// Symbol is the symbol argument value.
 			Symbol string
 		}
+		// Maintenance holds details about calls to the Maintenance method.
+		Maintenance []struct {
+		}
+		// NewsFlag holds details about calls to the NewsFlag method.
+		NewsFlag []struct {
+			// Symbol is the symbol argument value.
+			Symbol string
+		}
 		// Set holds details about calls to the Set method.
 		Set []struct {
 			// Symbols is the symbols argument value.
 			Symbols []*exchange.Symbol
 		}
+		// SetMaintenance holds details about calls to the SetMaintenance method.
+		SetMaintenance []struct {
+			// Maintenance is the maintenance argument value.
+			Maintenance bool
+		}
+		// SetNewsFlags holds details about calls to the SetNewsFlags method.
+		SetNewsFlags []struct {
+			// Flags is the flags argument value.
+			Flags map[string]string
+		}
 		// Symbols holds details about calls to the Symbols method.
 		Symbols []struct {
 		}
 	}
-	lockGet     sync.RWMutex
-	lockSet     sync.RWMutex
-	lockSymbols sync.RWMutex
+	lockGet            sync.RWMutex
+	lockMaintenance    sync.RWMutex
+	lockNewsFlag       sync.RWMutex
+	lockSet            sync.RWMutex
+	lockSetMaintenance sync.RWMutex
+	lockSetNewsFlags   sync.RWMutex
+	lockSymbols        sync.RWMutex
 }
 
 // Get calls GetFunc.
@@ -97,6 +143,65 @@ func (mock *ExchangeMock) GetCalls() []struct {
 	return calls
 }
 
+// Maintenance calls MaintenanceFunc.
+func (mock *ExchangeMock) Maintenance() bool {
+	if mock.MaintenanceFunc == nil {
+		panic("ExchangeMock.MaintenanceFunc: method is nil but Exchange.Maintenance was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockMaintenance.Lock()
+	mock.calls.Maintenance = append(mock.calls.Maintenance, callInfo)
+	mock.lockMaintenance.Unlock()
+	return mock.MaintenanceFunc()
+}
+
+// MaintenanceCalls gets all the calls that were made to Maintenance.
+// Check the length with:
+//
+//	len(mockedExchange.MaintenanceCalls())
+func (mock *ExchangeMock) MaintenanceCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockMaintenance.RLock()
+	calls = mock.calls.Maintenance
+	mock.lockMaintenance.RUnlock()
+	return calls
+}
+
+// NewsFlag calls NewsFlagFunc.
+func (mock *ExchangeMock) NewsFlag(symbol string) (string, bool) {
+	if mock.NewsFlagFunc == nil {
+		panic("ExchangeMock.NewsFlagFunc: method is nil but Exchange.NewsFlag was just called")
+	}
+	callInfo := struct {
+		Symbol string
+	}{
+		Symbol: symbol,
+	}
+	mock.lockNewsFlag.Lock()
+	mock.calls.NewsFlag = append(mock.calls.NewsFlag, callInfo)
+	mock.lockNewsFlag.Unlock()
+	return mock.NewsFlagFunc(symbol)
+}
+
+// NewsFlagCalls gets all the calls that were made to NewsFlag.
+// Check the length with:
+//
+//	len(mockedExchange.NewsFlagCalls())
+func (mock *ExchangeMock) NewsFlagCalls() []struct {
+	Symbol string
+} {
+	var calls []struct {
+		Symbol string
+	}
+	mock.lockNewsFlag.RLock()
+	calls = mock.calls.NewsFlag
+	mock.lockNewsFlag.RUnlock()
+	return calls
+}
+
 // Set calls SetFunc.
 func (mock *ExchangeMock) Set(symbols []*exchange.Symbol) {
 	if mock.SetFunc == nil {
@@ -129,6 +234,70 @@ func (mock *ExchangeMock) SetCalls() []struct {
 	return calls
 }
 
+// SetMaintenance calls SetMaintenanceFunc.
+func (mock *ExchangeMock) SetMaintenance(maintenance bool) {
+	if mock.SetMaintenanceFunc == nil {
+		panic("ExchangeMock.SetMaintenanceFunc: method is nil but Exchange.SetMaintenance was just called")
+	}
+	callInfo := struct {
+		Maintenance bool
+	}{
+		Maintenance: maintenance,
+	}
+	mock.lockSetMaintenance.Lock()
+	mock.calls.SetMaintenance = append(mock.calls.SetMaintenance, callInfo)
+	mock.lockSetMaintenance.Unlock()
+	mock.SetMaintenanceFunc(maintenance)
+}
+
+// SetMaintenanceCalls gets all the calls that were made to SetMaintenance.
+// Check the length with:
+//
+//	len(mockedExchange.SetMaintenanceCalls())
+func (mock *ExchangeMock) SetMaintenanceCalls() []struct {
+	Maintenance bool
+} {
+	var calls []struct {
+		Maintenance bool
+	}
+	mock.lockSetMaintenance.RLock()
+	calls = mock.calls.SetMaintenance
+	mock.lockSetMaintenance.RUnlock()
+	return calls
+}
+
+// SetNewsFlags calls SetNewsFlagsFunc.
+func (mock *ExchangeMock) SetNewsFlags(flags map[string]string) {
+	if mock.SetNewsFlagsFunc == nil {
+		panic("ExchangeMock.SetNewsFlagsFunc: method is nil but Exchange.SetNewsFlags was just called")
+	}
+	callInfo := struct {
+		Flags map[string]string
+	}{
+		Flags: flags,
+	}
+	mock.lockSetNewsFlags.Lock()
+	mock.calls.SetNewsFlags = append(mock.calls.SetNewsFlags, callInfo)
+	mock.lockSetNewsFlags.Unlock()
+	mock.SetNewsFlagsFunc(flags)
+}
+
+// SetNewsFlagsCalls gets all the calls that were made to SetNewsFlags.
+// Check the length with:
+//
+//	len(mockedExchange.SetNewsFlagsCalls())
+func (mock *ExchangeMock) SetNewsFlagsCalls() []struct {
+	Flags map[string]string
+} {
+	var calls []struct {
+		Flags map[string]string
+	}
+	mock.lockSetNewsFlags.RLock()
+	calls = mock.calls.SetNewsFlags
+	mock.lockSetNewsFlags.RUnlock()
+	return calls
+}
+
 // Symbols calls SymbolsFunc.
 func (mock *ExchangeMock) Symbols() []string {
 	if mock.SymbolsFunc == nil {
@@ -0,0 +1,185 @@
+// Package chart renders a small PNG candlestick snapshot, e.g. the
+// entry/exit/stop of a just-closed trade for orderer's trade-completed
+// Telegram notification, so it's reviewable at a glance without
+// leaving the chat. It draws directly with the standard image/
+// image/draw/image/png packages instead of depending on a plotting
+// library, since this module vendors none.
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+const (
+	width     = 480
+	height    = 240
+	padding   = 10
+	candleGap = 2
+)
+
+var (
+	colorBackground = color.RGBA{24, 26, 32, 255}
+	colorBullish    = color.RGBA{38, 166, 91, 255}
+	colorBearish    = color.RGBA{217, 48, 48, 255}
+	colorDefault    = color.RGBA{200, 200, 200, 255}
+)
+
+// Level is one horizontal reference line drawn across the chart, e.g.
+// a position's entry, stop-loss, or take-profit price. Label selects
+// its color: "entry" is white, "stop" is red, "take_profit" is green,
+// anything else falls back to gray.
+type Level struct {
+	Label string
+	Price float64
+}
+
+// Render draws candles as a simple OHLC candlestick chart with levels
+// overlaid as horizontal lines, and returns the result PNG-encoded.
+// It returns an error only if PNG encoding fails; a nil or empty
+// candles renders a blank chart with just the level lines.
+func Render(candles []*models.Candlestick, levels []Level) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{colorBackground}, image.Point{}, draw.Src)
+
+	lo, hi := priceRange(candles, levels)
+	if hi <= lo {
+		hi = lo + 1
+	}
+
+	plotWidth := width - 2*padding
+	plotHeight := height - 2*padding
+
+	toY := func(price float64) int {
+		ratio := (price - lo) / (hi - lo)
+		y := height - padding - int(ratio*float64(plotHeight))
+		if y < 0 {
+			y = 0
+		}
+		if y >= height {
+			y = height - 1
+		}
+		return y
+	}
+
+	if n := len(candles); n > 0 {
+		slot := float64(plotWidth) / float64(n)
+		bodyWidth := int(slot) - candleGap
+		if bodyWidth < 1 {
+			bodyWidth = 1
+		}
+
+		for i, candle := range candles {
+			open := helpers.StringToFloat(candle.Open)
+			close := helpers.StringToFloat(candle.Close)
+			high := helpers.StringToFloat(candle.High)
+			low := helpers.StringToFloat(candle.Low)
+
+			col := colorBearish
+			if close >= open {
+				col = colorBullish
+			}
+
+			x := padding + int(float64(i)*slot)
+			wickX := x + bodyWidth/2
+			drawVerticalLine(img, wickX, toY(high), toY(low), col)
+
+			top, bottom := toY(open), toY(close)
+			if top > bottom {
+				top, bottom = bottom, top
+			}
+			if bottom == top {
+				bottom = top + 1
+			}
+			drawRect(img, x, top, x+bodyWidth, bottom, col)
+		}
+	}
+
+	for _, level := range levels {
+		if level.Price <= 0 {
+			continue
+		}
+		drawHorizontalLine(img, toY(level.Price), levelColor(level.Label))
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func levelColor(label string) color.Color {
+	switch label {
+	case "entry":
+		return color.White
+	case "stop":
+		return colorBearish
+	case "take_profit":
+		return colorBullish
+	default:
+		return colorDefault
+	}
+}
+
+// priceRange returns the low/high price bounds the chart needs to fit
+// every candle wick and level line.
+func priceRange(candles []*models.Candlestick, levels []Level) (lo, hi float64) {
+	first := true
+
+	consider := func(v float64) {
+		if first {
+			lo, hi = v, v
+			first = false
+			return
+		}
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+
+	for _, candle := range candles {
+		consider(helpers.StringToFloat(candle.High))
+		consider(helpers.StringToFloat(candle.Low))
+	}
+	for _, level := range levels {
+		if level.Price > 0 {
+			consider(level.Price)
+		}
+	}
+
+	return lo, hi
+}
+
+func drawVerticalLine(img *image.RGBA, x, y1, y2 int, col color.Color) {
+	if y1 > y2 {
+		y1, y2 = y2, y1
+	}
+	for y := y1; y <= y2; y++ {
+		img.Set(x, y, col)
+	}
+}
+
+func drawHorizontalLine(img *image.RGBA, y int, col color.Color) {
+	for x := 0; x < width; x++ {
+		img.Set(x, y, col)
+	}
+}
+
+func drawRect(img *image.RGBA, x1, y1, x2, y2 int, col color.Color) {
+	for x := x1; x < x2; x++ {
+		for y := y1; y < y2; y++ {
+			img.Set(x, y, col)
+		}
+	}
+}
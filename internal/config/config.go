@@ -0,0 +1,91 @@
+// Package config provides a typed snapshot of the process-wide viper
+// config values that are read exactly once and baked into a long-lived
+// object at startup (the logger, the telegram bot, the market cache,
+// the binance rate limiter, the gRPC/HTTP listener), so those call
+// sites stop reading viper ad hoc and get an explicit, injected
+// dependency instead.
+//
+// It deliberately does NOT cover every viper key in the tree. Trading
+// and risk knobs (position sizing, throttles, blacklists, ...) live in
+// *settings.Settings, not here, because they're meant to be mutated at
+// runtime (Telegram commands, profile switches) and persisted via
+// simpledb — a static, populate-once-at-startup struct can't serve
+// that. Market/crawler keys like market.intervals and
+// chart.candles.limit are read live via viper.Get* throughout
+// internal/server/crawler and internal/server/analyzer specifically so
+// startConfigReload's hot-reload (see internal/server/config_reload.go)
+// takes effect without a restart; centralizing them here would
+// silently break that.
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Config is read once at process startup via Load and injected into
+// whichever service needs it, instead of that service reading viper
+// itself.
+type Config struct {
+	Server  ServerConfig
+	Trading TradingConfig
+	Market  MarketConfig
+	Binance BinanceConfig
+	Notify  NotifyConfig
+}
+
+// ServerConfig holds the gRPC/HTTP listener port, baked into the
+// net.Listener at Server.Start and already treated as immutable by
+// startConfigReload.
+type ServerConfig struct {
+	Port int
+}
+
+// TradingConfig holds the logger's startup configuration.
+type TradingConfig struct {
+	LogPath string
+	LogJSON bool
+}
+
+// MarketConfig holds the market cache's startup configuration.
+type MarketConfig struct {
+	// CandlesLimit is how many candles cache.Market keeps per
+	// symbol/interval, baked into it at construction.
+	CandlesLimit int32
+}
+
+// BinanceConfig holds the binance.Client's rate limiter configuration,
+// baked into it at construction.
+type BinanceConfig struct {
+	RateLimitRequests int
+	RateLimitDuration time.Duration
+}
+
+// NotifyConfig holds the telegram bot's startup configuration.
+type NotifyConfig struct {
+	Token string
+}
+
+// Load reads Config from viper. Call once at startup.
+func Load() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port: viper.GetInt("server.port"),
+		},
+		Trading: TradingConfig{
+			LogPath: viper.GetString("trading.log_path"),
+			LogJSON: viper.GetBool("trading.log_json"),
+		},
+		Market: MarketConfig{
+			CandlesLimit: viper.GetInt32("chart.candles.limit"),
+		},
+		Binance: BinanceConfig{
+			RateLimitRequests: viper.GetInt("binance.rate_limit.requests"),
+			RateLimitDuration: viper.GetDuration("binance.rate_limit.duration"),
+		},
+		Notify: NotifyConfig{
+			Token: viper.GetString("telegram.token"),
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"math"
+	"strconv"
+)
+
+// RoundingMode selects how AlignWithMode rounds a value to a step size.
+type RoundingMode byte
+
+const (
+	RoundNearest RoundingMode = iota
+	RoundDown
+	RoundUp
+)
+
+// AlignWithMode rounds value to the precision implied by stepSize using
+// mode, unlike AlignPrice/AlignQuantity which always round to nearest.
+// Quantities should round down so an order never asks for more than was
+// intended, and prices should round toward the passive side of the book
+// they're quoted against so a limit order never crosses the spread.
+func AlignWithMode(value float64, stepSize string, mode RoundingMode) float64 {
+	step, _ := strconv.ParseFloat(stepSize, 64)
+	if step == 0 {
+		return value
+	}
+
+	// Round the log10 itself before truncating to an int: step sizes
+	// like 0.1 aren't exact in binary float, so int(-math.Log10(0.1))
+	// truncates to 0 instead of 1 without this.
+	precision := int(math.Round(-math.Log10(step)))
+	round := math.Pow10(precision)
+
+	switch mode {
+	case RoundDown:
+		return math.Floor(value*round) / round
+	case RoundUp:
+		return math.Ceil(value*round) / round
+	default:
+		return math.Round(value*round) / round
+	}
+}
+
+// AlignQuantityDown rounds a quantity down to stepSize, so a sized
+// order never requests more than was intended.
+func AlignQuantityDown(quantity float64, stepSize string) float64 {
+	return AlignWithMode(quantity, stepSize, RoundDown)
+}
+
+// AlignPriceTowardPassive rounds price toward the passive side of the
+// book it's quoted against: bids round down, asks round up, so the
+// aligned price never crosses through the level it was computed from.
+func AlignPriceTowardPassive(price float64, stepSize string, isBid bool) float64 {
+	if isBid {
+		return AlignWithMode(price, stepSize, RoundDown)
+	}
+	return AlignWithMode(price, stepSize, RoundUp)
+}
@@ -0,0 +1,56 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlignWithMode(t *testing.T) {
+	type args struct {
+		value    float64
+		stepSize string
+		mode     RoundingMode
+	}
+	tests := []struct {
+		name string
+		args args
+		want float64
+	}{
+		{
+			name: "round down quantity against BTCUSDT step size",
+			args: args{value: 1.23456, stepSize: "0.001", mode: RoundDown},
+			want: 1.234,
+		},
+		{
+			name: "round up price against BTCUSDT tick size",
+			args: args{value: 27123.456, stepSize: "0.1", mode: RoundUp},
+			want: 27123.5,
+		},
+		{
+			name: "round nearest matches AlignPrice for whole tick size",
+			args: args{value: 27123.46, stepSize: "1", mode: RoundNearest},
+			want: 27123,
+		},
+		{
+			name: "zero step size is a no-op",
+			args: args{value: 42.4242, stepSize: "0", mode: RoundDown},
+			want: 42.4242,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AlignWithMode(tt.args.value, tt.args.stepSize, tt.args.mode))
+		})
+	}
+}
+
+func TestAlignQuantityDown(t *testing.T) {
+	assert.Equal(t, 2.489, AlignQuantityDown(2.48941996515, "0.001"))
+	assert.Equal(t, 2.0, AlignQuantityDown(2.9999, "1"))
+}
+
+func TestAlignPriceTowardPassive(t *testing.T) {
+	assert.Equal(t, 100.10, AlignPriceTowardPassive(100.101, "0.01", true))
+	assert.Equal(t, 100.11, AlignPriceTowardPassive(100.101, "0.01", false))
+}
@@ -0,0 +1,30 @@
+package helpers
+
+// Percent and Fraction exist because several settings fields have used
+// "Percent" in their name for a 0-1 fraction (e.g. what became
+// BreakEvenFeeBufferFraction) while others used it for an actual 0-100
+// percentage (e.g. MaxDrawdownPercent), and nothing at the model boundary
+// caught the mismatch. New percent-like config should be typed as one of
+// these instead of a bare float64, so the compiler enforces which scale a
+// value is on and ToFraction/ToPercent make a deliberate conversion
+// visible at the call site instead of an implicit /100 or *100.
+
+// Percent is a value on a 0-100 scale, e.g. MaxDrawdownPercent: 25
+// meaning 25%.
+type Percent float64
+
+// ToFraction converts p to the equivalent 0-1 scale, e.g. Percent(25) ->
+// Fraction(0.25).
+func (p Percent) ToFraction() Fraction {
+	return Fraction(p / 100)
+}
+
+// Fraction is a value on a 0-1 scale, e.g. a commission rate of 0.0004
+// meaning 0.04%.
+type Fraction float64
+
+// ToPercent converts f to the equivalent 0-100 scale, e.g. Fraction(0.25)
+// -> Percent(25).
+func (f Fraction) ToPercent() Percent {
+	return Percent(f * 100)
+}
@@ -0,0 +1,79 @@
+// Package fault provides a configurable fault injector for exercising
+// resilience behaviors (retries, degradation, reconciliation) against
+// dependencies that don't fail on their own in tests, such as
+// internal/services/binance/simulated and internal/libs/queue.
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// ErrInjected is returned by an injection point when Injector.ShouldError trips.
+var ErrInjected = errors.New("fault: injected error")
+
+// Injector decides, per call, whether to simulate an error, a slow
+// response, or a malformed payload. A zero-value Injector never trips.
+type Injector struct {
+	// ErrorRate is the probability (0-1) that ShouldError reports true.
+	ErrorRate float64
+	// TimeoutRate is the probability (0-1) that MaybeDelay blocks for Timeout.
+	TimeoutRate float64
+	// MalformedRate is the probability (0-1) that ShouldMalform reports true.
+	MalformedRate float64
+	// Timeout is how long MaybeDelay blocks when the timeout fault trips.
+	Timeout time.Duration
+}
+
+// New returns an Injector with the given rates and timeout. A rate of
+// 0 disables that fault entirely.
+func New(errorRate, timeoutRate, malformedRate float64, timeout time.Duration) *Injector {
+	return &Injector{
+		ErrorRate:     errorRate,
+		TimeoutRate:   timeoutRate,
+		MalformedRate: malformedRate,
+		Timeout:       timeout,
+	}
+}
+
+func (i *Injector) trips(rate float64) bool {
+	if i == nil || rate <= 0 {
+		return false
+	}
+	return rand.Float64() < rate
+}
+
+// ShouldError reports whether the error fault trips on this call.
+func (i *Injector) ShouldError() bool {
+	if i == nil {
+		return false
+	}
+	return i.trips(i.ErrorRate)
+}
+
+// ShouldMalform reports whether the malformed-payload fault trips on
+// this call.
+func (i *Injector) ShouldMalform() bool {
+	if i == nil {
+		return false
+	}
+	return i.trips(i.MalformedRate)
+}
+
+// MaybeDelay blocks for Timeout when the timeout fault trips, or until
+// ctx is done, whichever comes first, simulating a slow upstream.
+func (i *Injector) MaybeDelay(ctx context.Context) {
+	if i == nil || !i.trips(i.TimeoutRate) || i.Timeout <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(i.Timeout)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
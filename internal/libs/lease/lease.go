@@ -0,0 +1,57 @@
+package lease
+
+import (
+	"sync"
+	"time"
+)
+
+// Manager grants exclusive, TTL-bounded leases keyed by an arbitrary key
+// (e.g. a trading symbol), so two concurrent workers can't both hold the
+// same key at once, and a lease a holder forgets to release still
+// self-expires instead of deadlocking every future caller.
+type Manager struct {
+	mux    sync.Mutex
+	ttl    time.Duration
+	leases map[string]time.Time // key -> expiry
+}
+
+// New returns a Manager whose leases expire after ttl unless released
+// earlier.
+func New(ttl time.Duration) *Manager {
+	return &Manager{
+		ttl:    ttl,
+		leases: make(map[string]time.Time),
+	}
+}
+
+// Acquire grants the lease for key if it's free or its previous lease
+// has expired, returning true on success.
+func (m *Manager) Acquire(key string) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if expiry, held := m.leases[key]; held && time.Now().Before(expiry) {
+		return false
+	}
+
+	m.leases[key] = time.Now().Add(m.ttl)
+	return true
+}
+
+// Release frees the lease for key, so the next Acquire on it can succeed
+// immediately.
+func (m *Manager) Release(key string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	delete(m.leases, key)
+}
+
+// Held reports whether key is currently leased and not expired.
+func (m *Manager) Held(key string) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	expiry, held := m.leases[key]
+	return held && time.Now().Before(expiry)
+}
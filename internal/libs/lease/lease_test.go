@@ -0,0 +1,70 @@
+package lease
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcquireRejectsConcurrentHolder(t *testing.T) {
+	manager := New(time.Minute)
+
+	if !manager.Acquire("BTCUSDT") {
+		t.Fatal("first acquire should succeed")
+	}
+
+	if manager.Acquire("BTCUSDT") {
+		t.Error("a second acquire while the lease is still held should fail")
+	}
+
+	if !manager.Acquire("ETHUSDT") {
+		t.Error("a different key should still be acquirable")
+	}
+}
+
+func TestReleaseAllowsImmediateReacquire(t *testing.T) {
+	manager := New(time.Minute)
+
+	manager.Acquire("BTCUSDT")
+	manager.Release("BTCUSDT")
+
+	if !manager.Acquire("BTCUSDT") {
+		t.Error("acquire after release should succeed immediately")
+	}
+}
+
+func TestExpiredLeaseSelfExpires(t *testing.T) {
+	manager := New(time.Millisecond)
+
+	manager.Acquire("BTCUSDT")
+	time.Sleep(5 * time.Millisecond)
+
+	if manager.Held("BTCUSDT") {
+		t.Error("Held should report false once the lease's ttl has passed")
+	}
+
+	if !manager.Acquire("BTCUSDT") {
+		t.Error("acquire should succeed once the previous lease expired, even without an explicit Release")
+	}
+}
+
+// TestReleaseAfterEarlyReturn locks in the caller pattern open.go relies
+// on (Acquire + defer Release), confirming the lease is freed even when
+// the caller bails out before doing any work -- a forgotten Release on
+// one of those early-return paths would deadlock every future Acquire
+// on the symbol.
+func TestReleaseAfterEarlyReturn(t *testing.T) {
+	manager := New(time.Minute)
+
+	acquireAndBailOut := func(key string) {
+		if !manager.Acquire(key) {
+			return
+		}
+		defer manager.Release(key)
+	}
+
+	acquireAndBailOut("BTCUSDT")
+
+	if !manager.Acquire("BTCUSDT") {
+		t.Error("lease should have been released by the deferred call despite the early return")
+	}
+}
@@ -1,19 +1,29 @@
 package queue
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
+
+	"github.com/anvh2/futures-trading/internal/libs/fault"
 )
 
 const (
 	defaultRetention time.Duration = time.Hour
+	// defaultLeaseTTL bounds how long a message stays leased to whichever
+	// consumer last received it from Peak/Consume without a Commit. If
+	// the consumer crashes before committing, the lease expires and the
+	// message becomes eligible for redelivery instead of sitting behind
+	// a lease nothing will ever release.
+	defaultLeaseTTL time.Duration = 30 * time.Second
 )
 
 type Message struct {
-	expire time.Time
-	Offset int64
-	Data   interface{}
+	expire      time.Time
+	leaseExpire time.Time
+	Offset      int64
+	Data        interface{}
 }
 
 type Consumer struct {
@@ -23,19 +33,31 @@ type Consumer struct {
 
 type Queue struct {
 	lock      *sync.Mutex
+	cond      *sync.Cond
 	length    int64
 	table     map[int64]*Message
 	consumers map[string]*Consumer
 	quit      chan struct{}
+	closed    bool
+	leaseTTL  time.Duration
+
+	// faults, when set via InjectFaults, drops pushed messages and
+	// delays/errors Peak calls, so consumers' retry/reconciliation
+	// paths can be exercised without a real flaky broker.
+	faults *fault.Injector
 }
 
 func New() *Queue {
+	lock := &sync.Mutex{}
+
 	queue := &Queue{
-		lock:      &sync.Mutex{},
+		lock:      lock,
+		cond:      sync.NewCond(lock),
 		length:    0,
 		table:     make(map[int64]*Message),
 		consumers: make(map[string]*Consumer),
 		quit:      make(chan struct{}),
+		leaseTTL:  defaultLeaseTTL,
 	}
 
 	// ensure there are no memory leaks
@@ -46,12 +68,25 @@ func New() *Queue {
 		for {
 			select {
 			case <-ticker.C:
+				// Hold q.lock for the whole sweep, not just inside
+				// remove -- Push/Commit/next all mutate queue.table
+				// under the lock, so ranging over it unsynchronized
+				// races against them.
+				queue.lock.Lock()
+
+				now := time.Now()
 				for offset, msg := range queue.table {
-					if msg.expire.Before(time.Now()) {
-						queue.remove(offset)
+					if msg.expire.Before(now) {
+						delete(queue.table, offset)
 					}
 				}
 
+				// wake any Consume call blocked waiting on a lease that
+				// has since expired, so it re-checks and redelivers
+				// instead of waiting for the next Push.
+				queue.cond.Broadcast()
+				queue.lock.Unlock()
+
 			case <-queue.quit:
 				return
 			}
@@ -61,11 +96,37 @@ func New() *Queue {
 	return queue
 }
 
-func (q *Queue) remove(offset int64) {
+// InjectFaults configures q to randomly drop pushed messages and
+// delay/error on Peak, per injector's rates; see internal/libs/fault.
+// Passing nil disables fault injection.
+func (q *Queue) InjectFaults(injector *fault.Injector) {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 
-	delete(q.table, offset)
+	q.faults = injector
+}
+
+// Depth returns how many pushed messages consumerId has not yet
+// consumed, so callers (e.g. a load test) can observe backlog building
+// up under load instead of only seeing throughput. Unknown consumers
+// are reported as fully behind, i.e. the total queue length.
+func (q *Queue) Depth(consumerId string) int64 {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	consumer, ok := q.consumers[consumerId]
+	if !ok {
+		return q.length
+	}
+
+	// currentOffset is the next offset to consume, and offsets start at
+	// 1, so everything before it has already been consumed.
+	consumed := consumer.currentOffset - 1
+	if consumed < 0 {
+		consumed = 0
+	}
+
+	return q.length - consumed
 }
 
 func (q *Queue) Register(consumerId string) *Consumer {
@@ -93,6 +154,10 @@ func (q *Queue) Push(data interface{}, expire time.Duration) error {
 	q.lock.Lock()
 	defer q.lock.Unlock()
 
+	if q.faults.ShouldError() {
+		return errors.New("queue: message dropped (fault injection)")
+	}
+
 	q.length++
 
 	msg := &Message{
@@ -102,18 +167,17 @@ func (q *Queue) Push(data interface{}, expire time.Duration) error {
 	}
 
 	q.table[q.length] = msg
+	q.cond.Broadcast()
 
 	return nil
 }
 
-func (q *Queue) Peak(consumerId string) (*Message, error) {
-	q.lock.Lock()
-	defer q.lock.Unlock()
-
-	consumer, ok := q.consumers[consumerId]
-	if !ok {
-		consumer = q.Register(consumerId)
-	}
+// next returns the earliest not-yet-committed, not-currently-leased
+// message available to consumer, leasing it for q.leaseTTL before
+// returning it, or nil if consumer has caught up or the next message
+// is still within another, uncommitted lease. Caller must hold q.lock.
+func (q *Queue) next(consumer *Consumer) *Message {
+	now := time.Now()
 
 	for consumer.currentOffset <= q.length {
 		msg, ok := q.table[consumer.currentOffset]
@@ -122,18 +186,128 @@ func (q *Queue) Peak(consumerId string) (*Message, error) {
 			continue
 		}
 
-		if msg.expire.Before(time.Now()) {
+		if msg.expire.Before(now) {
 			delete(q.table, consumer.currentOffset)
 			consumer.currentOffset++
 			continue
 		}
 
+		if msg.leaseExpire.After(now) {
+			return nil
+		}
+
+		msg.leaseExpire = now.Add(q.leaseTTL)
+		return msg
+	}
+
+	return nil
+}
+
+// Commit acknowledges that consumerId finished processing the message
+// at offset, removing it and advancing past it, so Peak/Consume never
+// redeliver it. If the consumer crashes before calling Commit, the
+// message's lease (set by next) simply expires and the same offset
+// becomes eligible for redelivery instead of blocking that consumer
+// forever.
+func (q *Queue) Commit(consumerId string, offset int64) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	consumer, ok := q.consumers[consumerId]
+	if !ok {
+		return errors.New("queue: unknown consumer")
+	}
+
+	delete(q.table, offset)
+
+	if offset == consumer.currentOffset {
+		consumer.currentOffset++
+	}
+
+	q.cond.Broadcast()
+
+	return nil
+}
+
+func (q *Queue) Peak(consumerId string) (*Message, error) {
+	q.faults.MaybeDelay(context.Background())
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.faults.ShouldError() {
+		return nil, errors.New("notfound")
+	}
+
+	consumer, ok := q.consumers[consumerId]
+	if !ok {
+		// Inline instead of calling Register, which also takes q.lock --
+		// sync.Mutex isn't reentrant, so that would deadlock here.
+		consumer = &Consumer{ConsumerId: consumerId}
+		q.consumers[consumerId] = consumer
+	}
+
+	if msg := q.next(consumer); msg != nil {
 		return msg, nil
 	}
 
 	return nil, errors.New("notfound")
 }
 
+// Consume blocks until a message is available for consumerId, ctx is
+// done, or the queue is closed, instead of a caller polling Peak on a
+// ticker. It otherwise behaves exactly like Peak.
+func (q *Queue) Consume(ctx context.Context, consumerId string) (*Message, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	consumer, ok := q.consumers[consumerId]
+	if !ok {
+		consumer = &Consumer{ConsumerId: consumerId}
+		q.consumers[consumerId] = consumer
+	}
+
+	if done := ctx.Done(); done != nil {
+		stop := make(chan struct{})
+		defer close(stop)
+
+		go func() {
+			select {
+			case <-done:
+				q.lock.Lock()
+				q.cond.Broadcast()
+				q.lock.Unlock()
+			case <-stop:
+			}
+		}()
+	}
+
+	for {
+		if q.closed {
+			return nil, errors.New("queue: closed")
+		}
+
+		if q.faults.ShouldError() {
+			return nil, errors.New("notfound")
+		}
+
+		if msg := q.next(consumer); msg != nil {
+			return msg, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		q.cond.Wait()
+	}
+}
+
 func (q *Queue) Close() {
+	q.lock.Lock()
+	q.closed = true
+	q.lock.Unlock()
+
 	close(q.quit)
+	q.cond.Broadcast()
 }
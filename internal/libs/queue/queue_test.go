@@ -18,3 +18,34 @@ func TestQueue(t *testing.T) {
 	data, err := q.Peak("bar")
 	fmt.Println(data.Data, err)
 }
+
+func TestQueueLeaseAndCommit(t *testing.T) {
+	q := New()
+	q.Register("consumer")
+
+	q.Push("hello", time.Minute)
+
+	first, err := q.Peak("consumer")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := q.Peak("consumer"); err == nil {
+		t.Fatal("expected message to stay leased until committed")
+	}
+
+	if err := q.Commit("consumer", first.Offset); err != nil {
+		t.Fatalf("unexpected commit error: %v", err)
+	}
+
+	q.Push("world", time.Minute)
+
+	second, err := q.Peak("consumer")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if second.Data != "world" {
+		t.Fatalf("expected next message after commit, got %v", second.Data)
+	}
+}
@@ -0,0 +1,91 @@
+package simpledb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const defaultLocalRetention = 10
+
+// RemoteBackend uploads a backup file's bytes to durable storage outside
+// the local disk, e.g. S3 or a MinIO-compatible bucket.
+type RemoteBackend interface {
+	Upload(key string, data []byte) error
+}
+
+// BackupConfig controls where Backup writes copies of the DB file.
+type BackupConfig struct {
+	// LocalRetention is the number of rotated local copies to keep.
+	// Defaults to 10 when zero.
+	LocalRetention int
+	// Remote is optional; when set, every backup is also uploaded there.
+	Remote RemoteBackend
+}
+
+// Backup copies the current DB file to a timestamped sibling, prunes
+// local copies beyond cfg.LocalRetention, and uploads to cfg.Remote when
+// configured. The local copy and rotation run before the upload, so a
+// failed upload still leaves the local backup in place; a failed local
+// write returns before the upload is attempted.
+//
+// Backup takes the same lock Save/Load do, so it can't read a file
+// that's mid-write on a concurrent Save.
+func (db *DB) Backup(cfg *BackupConfig) error {
+	if cfg == nil {
+		cfg = &BackupConfig{}
+	}
+	if cfg.LocalRetention <= 0 {
+		cfg.LocalRetention = defaultLocalRetention
+	}
+
+	db.lock.Lock()
+	data, err := os.ReadFile(db.path)
+	db.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	backupPath := fmt.Sprintf("%s.%d.bak", db.path, time.Now().UnixMilli())
+	if err := os.WriteFile(backupPath, data, 0644); err != nil {
+		return err
+	}
+
+	if err := rotateLocalBackups(db.path, cfg.LocalRetention); err != nil {
+		return err
+	}
+
+	if cfg.Remote != nil {
+		key := filepath.Base(backupPath)
+		if err := cfg.Remote.Upload(key, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rotateLocalBackups keeps only the most recent `retention` backup files
+// for db path, removing older ones.
+func rotateLocalBackups(path string, retention int) error {
+	matches, err := filepath.Glob(fmt.Sprintf("%s.*.bak", path))
+	if err != nil {
+		return err
+	}
+
+	if len(matches) <= retention {
+		return nil
+	}
+
+	sort.Strings(matches)
+
+	for _, stale := range matches[:len(matches)-retention] {
+		if err := os.Remove(stale); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package simpledb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+var errChecksumMismatch = errors.New("simpledb: checksum mismatch, state file may be corrupt")
+
+// envelope wraps the persisted document with a checksum of its bytes, so
+// a truncated or corrupted write is detected on Load instead of silently
+// handed to the caller.
+type envelope struct {
+	Checksum string          `json:"checksum"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// fileLocks serializes Save/Load per backing file across every DB
+// instance opened on that path, since concurrent Save calls would
+// otherwise race on the same file.
+var (
+	fileLocksMux sync.Mutex
+	fileLocks    = make(map[string]*sync.Mutex)
+)
+
+func lockFor(path string) *sync.Mutex {
+	fileLocksMux.Lock()
+	defer fileLocksMux.Unlock()
+
+	lock, ok := fileLocks[path]
+	if !ok {
+		lock = &sync.Mutex{}
+		fileLocks[path] = lock
+	}
+
+	return lock
+}
+
+// DB is a minimal JSON-file backed key-value-of-one store: it persists a
+// single document to disk, used for small pieces of state that don't
+// warrant a real database (trading state, settings snapshots, ...).
+type DB struct {
+	path string
+	lock *sync.Mutex
+}
+
+// Open returns a DB backed by the file at path. The file is created on
+// the first Save, it does not need to exist yet.
+func Open(path string) *DB {
+	return &DB{path: path, lock: lockFor(path)}
+}
+
+// Save serializes v as JSON, wraps it with a checksum, and writes it to
+// the backing file. Concurrent Save/Load calls on the same path are
+// serialized.
+func (db *DB) Save(v interface{}) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	env := &envelope{
+		Checksum: checksum(data),
+		Data:     data,
+	}
+
+	out, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(db.path, out, 0644)
+}
+
+// Load reads the backing file, verifies its checksum, and unmarshals its
+// data into v.
+func (db *DB) Load(v interface{}) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	raw, err := os.ReadFile(db.path)
+	if err != nil {
+		return err
+	}
+
+	env := &envelope{}
+	if err := json.Unmarshal(raw, env); err != nil {
+		return err
+	}
+
+	if env.Checksum != checksum(env.Data) {
+		return errChecksumMismatch
+	}
+
+	return json.Unmarshal(env.Data, v)
+}
+
+// Load reads the file backing db and unmarshals it into a value of type
+// T, returning it directly instead of requiring the caller to allocate
+// a target first.
+func Load[T any](db *DB) (*T, error) {
+	v := new(T)
+	if err := db.Load(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
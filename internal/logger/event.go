@@ -0,0 +1,43 @@
+package logger
+
+import "go.uber.org/zap"
+
+// EventSeverity ranks how urgently a structured log event should be
+// surfaced by downstream alerting, independent of the zap log level the
+// line was written at.
+type EventSeverity string
+
+const (
+	SeverityInfo     EventSeverity = "info"
+	SeverityWarning  EventSeverity = "warning"
+	SeverityCritical EventSeverity = "critical"
+)
+
+// Event carries the fields a structured log line should include so
+// events can be queried by type across services once shipped to
+// Loki/Elastic, regardless of which service emitted them.
+type Event struct {
+	Type       string
+	Symbol     string
+	DecisionID string
+	Severity   EventSeverity
+}
+
+// Fields renders e as zap fields, to be spread into a Logger call
+// alongside any event-specific fields, e.g.
+// logger.Info("[Process] signal sent", event.Fields()...).
+func (e Event) Fields() []zap.Field {
+	fields := []zap.Field{zap.String("event_type", e.Type)}
+
+	if e.Symbol != "" {
+		fields = append(fields, zap.String("symbol", e.Symbol))
+	}
+	if e.DecisionID != "" {
+		fields = append(fields, zap.String("decision_id", e.DecisionID))
+	}
+	if e.Severity != "" {
+		fields = append(fields, zap.String("severity", string(e.Severity)))
+	}
+
+	return fields
+}
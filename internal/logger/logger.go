@@ -9,7 +9,11 @@ type Logger struct {
 	*zap.Logger
 }
 
-func New(file string) (*Logger, error) {
+// New builds a Logger writing to file. When jsonOutput is false, logs
+// encode as human-readable console lines instead of JSON; structured
+// event fields (see Event) are still attached either way, but JSON
+// output is required for a log shipper to query them per event type.
+func New(file string, jsonOutput bool) (*Logger, error) {
 	config := zap.NewProductionConfig()
 
 	config.OutputPaths = []string{file}
@@ -20,6 +24,10 @@ func New(file string) (*Logger, error) {
 	config.EncoderConfig.MessageKey = "message"
 	config.DisableStacktrace = true
 
+	if !jsonOutput {
+		config.Encoding = "console"
+	}
+
 	logger, err := config.Build()
 	if err != nil {
 		return nil, err
@@ -0,0 +1,56 @@
+// Package metrics holds prometheus collectors shared across packages
+// that otherwise have no common caller to wire them through, so
+// internal/safety and internal/server/orderer can both record against
+// the same counters without importing one another.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// RejectedDecisions counts every trading decision rejected before an
+// order was placed, labeled by reason, so it's visible via /metrics
+// whether the bot isn't trading because of confidence thresholds,
+// exposure caps, or something else entirely.
+var RejectedDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "trading",
+	Name:      "rejected_decisions_total",
+	Help:      "Total number of trading decisions rejected before an order was placed, by reason.",
+}, []string{"reason"})
+
+// GuardViolations counts every safety.Rule violation Guard evaluates,
+// labeled by the rule that tripped and its severity, whether or not
+// Guard is running in dry-run mode.
+var GuardViolations = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "trading",
+	Name:      "guard_violations_total",
+	Help:      "Total number of safety rule violations Guard has evaluated, by rule and severity.",
+}, []string{"rule", "severity"})
+
+// CandleIntegrityIssues counts every gap, duplicate, and out-of-order
+// candle crawler.Crawler's integrity check finds, labeled by
+// symbol/interval and the kind of issue, so a noisy feed shows up on
+// /metrics before it silently corrupts a decision.
+var CandleIntegrityIssues = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "trading",
+	Name:      "candle_integrity_issues_total",
+	Help:      "Total number of candle gaps, duplicates, and out-of-order timestamps found by the integrity check, by symbol, interval, and kind.",
+}, []string{"symbol", "interval", "kind"})
+
+// CandleIntegrityRepairs counts every symbol/interval backfill the
+// integrity check performed to repair an issue it found.
+var CandleIntegrityRepairs = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "trading",
+	Name:      "candle_integrity_repairs_total",
+	Help:      "Total number of symbol/interval backfills performed to repair a candle integrity issue.",
+}, []string{"symbol", "interval"})
+
+// ExchangeMaintenance reports whether Binance's system status was last
+// seen in an exchange-wide maintenance window (1) or normal (0), see
+// crawler.Crawler's maintenance check and safety.TradingStatusRule.
+var ExchangeMaintenance = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: "trading",
+	Name:      "exchange_maintenance",
+	Help:      "1 if Binance's system status last reported an exchange-wide maintenance window, 0 otherwise.",
+})
@@ -3,6 +3,7 @@ package models
 //CandleStick represents a single candle in the graph.
 import (
 	"encoding/json"
+	"strconv"
 )
 
 // CandleStick represents a single candlestick in a chart.
@@ -14,6 +15,10 @@ type Candlestick struct {
 	Close     string `json:"c,omitempty"`
 	Low       string `json:"l,omitempty"`
 	Volume    string `json:"v,omitempty"`
+	// Closed is whether this candle is the exchange-confirmed final bar
+	// for its period (Binance kline event's "x" field) rather than one
+	// still forming intrabar. See settings.RequireClosedCandle.
+	Closed bool `json:"closed,omitempty"`
 }
 
 // String returns the string representation of the object.
@@ -22,6 +27,37 @@ func (cs *Candlestick) String() string {
 	return string(b)
 }
 
+// HighFloat, OpenFloat, CloseFloat, LowFloat and VolumeFloat parse the
+// candle's OHLCV strings once, so callers and indicators don't each
+// repeat their own parse-and-pray. Unparsable values return 0, matching
+// the existing strconv.ParseFloat-and-ignore-error convention elsewhere.
+func (cs *Candlestick) HighFloat() float64 {
+	return stringToFloat(cs.High)
+}
+
+func (cs *Candlestick) OpenFloat() float64 {
+	return stringToFloat(cs.Open)
+}
+
+func (cs *Candlestick) CloseFloat() float64 {
+	return stringToFloat(cs.Close)
+}
+
+func (cs *Candlestick) LowFloat() float64 {
+	return stringToFloat(cs.Low)
+}
+
+func (cs *Candlestick) VolumeFloat() float64 {
+	return stringToFloat(cs.Volume)
+}
+
+// stringToFloat mirrors helpers.StringToFloat; models can't import
+// helpers here since helpers already imports models.
+func stringToFloat(val string) float64 {
+	result, _ := strconv.ParseFloat(val, 64)
+	return result
+}
+
 type CandlesData struct {
 	Candles    []*Candlestick
 	CreateTime int64 `json:"create_time"`
@@ -5,6 +5,32 @@ import (
 	"testing"
 )
 
+func TestCandlestickFloatAccessors(t *testing.T) {
+	cs := &Candlestick{
+		Low:    "10.5",
+		High:   "20.25",
+		Open:   "15",
+		Close:  "18.75",
+		Volume: "100",
+	}
+
+	if cs.LowFloat() != 10.5 {
+		t.Errorf("expected LowFloat 10.5, got %v", cs.LowFloat())
+	}
+	if cs.HighFloat() != 20.25 {
+		t.Errorf("expected HighFloat 20.25, got %v", cs.HighFloat())
+	}
+	if cs.OpenFloat() != 15 {
+		t.Errorf("expected OpenFloat 15, got %v", cs.OpenFloat())
+	}
+	if cs.CloseFloat() != 18.75 {
+		t.Errorf("expected CloseFloat 18.75, got %v", cs.CloseFloat())
+	}
+	if cs.VolumeFloat() != 100 {
+		t.Errorf("expected VolumeFloat 100, got %v", cs.VolumeFloat())
+	}
+}
+
 func TestMarshalChart(t *testing.T) {
 	chart := &CandleSummary{
 		Symbol: "BTCUSDT",
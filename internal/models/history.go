@@ -0,0 +1,204 @@
+package models
+
+// TradeResult represents the outcome of a single closed trade, used to
+// build up rolling statistics for risk sizing.
+type TradeResult struct {
+	Symbol   string  `json:"symbol,omitempty"`
+	Side     string  `json:"side,omitempty"`
+	Win      bool    `json:"win,omitempty"`
+	PNL      float64 `json:"pnl,omitempty"`
+	OpenedAt int64   `json:"opened_at,omitempty"`
+	ClosedAt int64   `json:"closed_at,omitempty"`
+	// RiskAmount is the amount risked at entry (e.g. stop-loss distance
+	// times quantity), used to express PNL as an R-multiple. Zero if
+	// unknown, in which case the trade is excluded from AvgR.
+	RiskAmount float64 `json:"risk_amount,omitempty"`
+	// FundingPaid is the net funding fee paid (positive) or received
+	// (negative) while the position was open.
+	FundingPaid float64 `json:"funding_paid,omitempty"`
+	// FeePaid is the round-trip commission charged on entry and exit,
+	// already deducted from PNL.
+	FeePaid float64 `json:"fee_paid,omitempty"`
+}
+
+// Archiver persists a TradeResult evicted from a TradingHistory's
+// in-memory window, so the full record survives somewhere durable
+// instead of being dropped once it ages out of the rolling window.
+type Archiver interface {
+	Archive(result *TradeResult) error
+}
+
+// TradingHistory keeps a rolling window of trade results so sizing and
+// risk rules can react to recent performance instead of fixed constants.
+type TradingHistory struct {
+	results  []*TradeResult
+	maxSize  int
+	archiver Archiver
+}
+
+// NewTradingHistory returns an empty history capped at maxSize entries.
+func NewTradingHistory(maxSize int) *TradingHistory {
+	if maxSize <= 0 {
+		maxSize = 100
+	}
+
+	return &TradingHistory{
+		results: make([]*TradeResult, 0, maxSize),
+		maxSize: maxSize,
+	}
+}
+
+// WithArchiver sets the Archiver Add hands evicted entries to before
+// they're dropped from the in-memory window, and returns h for
+// chaining.
+func (h *TradingHistory) WithArchiver(archiver Archiver) *TradingHistory {
+	h.archiver = archiver
+	return h
+}
+
+// Add appends a trade result, archiving and evicting the oldest entry
+// once maxSize is reached. It returns the Archiver's error, if any;
+// the new result is kept in the window regardless.
+func (h *TradingHistory) Add(result *TradeResult) error {
+	h.results = append(h.results, result)
+
+	if len(h.results) <= h.maxSize {
+		return nil
+	}
+
+	evicted := h.results[0]
+	h.results = h.results[len(h.results)-h.maxSize:]
+
+	if h.archiver == nil {
+		return nil
+	}
+
+	return h.archiver.Archive(evicted)
+}
+
+// Results returns the current rolling window, oldest first.
+func (h *TradingHistory) Results() []*TradeResult {
+	return h.results
+}
+
+// Len returns how many results are currently in the rolling window,
+// for callers paging through Page without fetching a page first.
+func (h *TradingHistory) Len() int {
+	return len(h.results)
+}
+
+// Page returns up to limit results starting offset entries back from
+// the most recent, most recent first, so a caller (e.g. a history
+// command) can page through recent trades without copying the full
+// window on every call. An offset at or past Len returns nil.
+func (h *TradingHistory) Page(offset, limit int) []*TradeResult {
+	if offset < 0 || limit <= 0 || offset >= len(h.results) {
+		return nil
+	}
+
+	end := len(h.results) - offset
+	start := end - limit
+	if start < 0 {
+		start = 0
+	}
+
+	page := make([]*TradeResult, end-start)
+	for i := range page {
+		page[i] = h.results[end-1-i]
+	}
+
+	return page
+}
+
+// WinRate returns the fraction of wins in the current window, or 0 if empty.
+func (h *TradingHistory) WinRate() float64 {
+	if len(h.results) == 0 {
+		return 0
+	}
+
+	wins := 0
+	for _, r := range h.results {
+		if r.Win {
+			wins++
+		}
+	}
+
+	return float64(wins) / float64(len(h.results))
+}
+
+// AvgWinLoss returns the average PNL of winning trades and the average
+// PNL (as a positive magnitude) of losing trades in the current window.
+func (h *TradingHistory) AvgWinLoss() (avgWin float64, avgLoss float64) {
+	var winSum, lossSum float64
+	var winCount, lossCount int
+
+	for _, r := range h.results {
+		if r.Win {
+			winSum += r.PNL
+			winCount++
+		} else {
+			lossSum += -r.PNL
+			lossCount++
+		}
+	}
+
+	if winCount > 0 {
+		avgWin = winSum / float64(winCount)
+	}
+	if lossCount > 0 {
+		avgLoss = lossSum / float64(lossCount)
+	}
+
+	return avgWin, avgLoss
+}
+
+// SymbolStats summarizes a symbol's recent performance, so an
+// underperforming symbol can be removed from the watchlist with
+// evidence instead of a gut call.
+type SymbolStats struct {
+	Symbol          string  `json:"symbol,omitempty"`
+	Trades          int     `json:"trades,omitempty"`
+	WinRate         float64 `json:"win_rate,omitempty"`
+	AvgR            float64 `json:"avg_r,omitempty"`
+	TotalPNL        float64 `json:"total_pnl,omitempty"`
+	TotalFunding    float64 `json:"total_funding,omitempty"`
+	TotalFees       float64 `json:"total_fees,omitempty"`
+	AvgTimeInMarket int64   `json:"avg_time_in_market_ms,omitempty"`
+}
+
+// Stats aggregates the current rolling window into SymbolStats for symbol.
+func (h *TradingHistory) Stats(symbol string) *SymbolStats {
+	stats := &SymbolStats{
+		Symbol:  symbol,
+		Trades:  len(h.results),
+		WinRate: h.WinRate(),
+	}
+
+	var rSum float64
+	var rCount int
+	var timeSum int64
+
+	for _, r := range h.results {
+		if r.RiskAmount > 0 {
+			rSum += r.PNL / r.RiskAmount
+			rCount++
+		}
+
+		stats.TotalPNL += r.PNL
+		stats.TotalFunding += r.FundingPaid
+		stats.TotalFees += r.FeePaid
+
+		if r.OpenedAt > 0 && r.ClosedAt > r.OpenedAt {
+			timeSum += r.ClosedAt - r.OpenedAt
+		}
+	}
+
+	if rCount > 0 {
+		stats.AvgR = rSum / float64(rCount)
+	}
+	if len(h.results) > 0 {
+		stats.AvgTimeInMarket = timeSum / int64(len(h.results))
+	}
+
+	return stats
+}
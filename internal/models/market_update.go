@@ -0,0 +1,13 @@
+package models
+
+// MarketUpdate is one symbol/interval's freshly updated candle and/or
+// computed indicator, published on internal/broadcast.Hub so other
+// in-process consumers (and, once SignalService gains a streaming
+// Subscribe RPC, external subscribers) can reuse the crawler's and
+// analyzer's work instead of reconnecting to Binance themselves.
+type MarketUpdate struct {
+	Symbol   string       `json:"symbol"`
+	Interval string       `json:"interval"`
+	Candle   *Candlestick `json:"candle,omitempty"`
+	Stoch    *Stoch       `json:"stoch,omitempty"`
+}
@@ -7,6 +7,11 @@ type Price struct {
 	Entry    float64 `json:"entry,omitempty"`
 	Profit   float64 `json:"profit,omitempty"`
 	Loss     float64 `json:"loss,omitemty"`
+	// Leverage is the leverage actually used to size Quantity, after
+	// settings.Settings.MaxLeverageFor has applied any per-strategy or
+	// per-symbol-tier cap to GetPreferLeverage's recommendation. See
+	// orderer.appraise.
+	Leverage int `json:"leverage,omitempty"`
 }
 
 func (p *Price) String() string {
@@ -0,0 +1,118 @@
+package models
+
+import "time"
+
+// SlippageRecord compares the price a decision was sized against to
+// the price its entry order actually filled at, so limit-vs-market
+// entry policy can be tuned against real execution data instead of a
+// gut call.
+type SlippageRecord struct {
+	Symbol        string  `json:"symbol,omitempty"`
+	Side          string  `json:"side,omitempty"`
+	ExpectedPrice float64 `json:"expected_price,omitempty"`
+	ActualPrice   float64 `json:"actual_price,omitempty"`
+	// SlippageBps is the signed difference between ActualPrice and
+	// ExpectedPrice in basis points, adverse-positive: a LONG filled
+	// above its expected price, or a SHORT filled below it, reports a
+	// positive number.
+	SlippageBps float64 `json:"slippage_bps,omitempty"`
+	// Hour is the UTC hour (0-23) the fill happened in, so slippage can
+	// be aggregated by time-of-day.
+	Hour      int   `json:"hour,omitempty"`
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
+
+// NewSlippageRecord resolves SlippageBps and Hour from expected/actual
+// and the fill time, so callers only need to supply the raw prices.
+func NewSlippageRecord(symbol, side string, expected, actual float64, filledAt time.Time) *SlippageRecord {
+	var bps float64
+	if expected > 0 {
+		bps = (actual - expected) / expected * 10000
+		if side == "SHORT" {
+			bps = -bps
+		}
+	}
+
+	return &SlippageRecord{
+		Symbol:        symbol,
+		Side:          side,
+		ExpectedPrice: expected,
+		ActualPrice:   actual,
+		SlippageBps:   bps,
+		Hour:          filledAt.UTC().Hour(),
+		Timestamp:     filledAt.UnixMilli(),
+	}
+}
+
+// SlippageHistory keeps a rolling window of SlippageRecords, mirroring
+// TradingHistory's fixed-capacity window so both can be tuned and
+// evicted the same way.
+type SlippageHistory struct {
+	records []*SlippageRecord
+	maxSize int
+}
+
+// NewSlippageHistory returns an empty history capped at maxSize entries.
+func NewSlippageHistory(maxSize int) *SlippageHistory {
+	if maxSize <= 0 {
+		maxSize = 100
+	}
+
+	return &SlippageHistory{
+		records: make([]*SlippageRecord, 0, maxSize),
+		maxSize: maxSize,
+	}
+}
+
+// Add appends record, dropping the oldest entry once maxSize is reached.
+func (h *SlippageHistory) Add(record *SlippageRecord) {
+	h.records = append(h.records, record)
+
+	if len(h.records) > h.maxSize {
+		h.records = h.records[len(h.records)-h.maxSize:]
+	}
+}
+
+// Records returns the current rolling window, oldest first.
+func (h *SlippageHistory) Records() []*SlippageRecord {
+	return h.records
+}
+
+// SlippageStats summarizes a symbol's recent fill slippage, by hour of
+// day, so an entry policy (limit vs market) can be tuned against when
+// slippage actually bites.
+type SlippageStats struct {
+	Symbol         string          `json:"symbol,omitempty"`
+	Fills          int             `json:"fills,omitempty"`
+	AvgSlippageBps float64         `json:"avg_slippage_bps,omitempty"`
+	ByHour         map[int]float64 `json:"by_hour_avg_slippage_bps,omitempty"`
+}
+
+// Stats aggregates the current rolling window into SlippageStats for symbol.
+func (h *SlippageHistory) Stats(symbol string) *SlippageStats {
+	stats := &SlippageStats{
+		Symbol: symbol,
+		Fills:  len(h.records),
+		ByHour: make(map[int]float64),
+	}
+
+	var sum float64
+	hourSums := make(map[int]float64)
+	hourCounts := make(map[int]int)
+
+	for _, r := range h.records {
+		sum += r.SlippageBps
+		hourSums[r.Hour] += r.SlippageBps
+		hourCounts[r.Hour]++
+	}
+
+	if len(h.records) > 0 {
+		stats.AvgSlippageBps = sum / float64(len(h.records))
+	}
+
+	for hour, count := range hourCounts {
+		stats.ByHour[hour] = hourSums[hour] / float64(count)
+	}
+
+	return stats
+}
@@ -1,6 +1,10 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/anvh2/futures-trading/internal/regime"
+)
 
 type Stoch struct {
 	RSI float64 `json:"rsi"`
@@ -8,9 +12,66 @@ type Stoch struct {
 	D   float64 `json:"d"`
 }
 
+// SignalSource identifies what produced an Oscillator, so the decision
+// engine and audit trail can tell a signal computed by the normal
+// indicator pipeline apart from one fed in by another path.
+type SignalSource string
+
+const (
+	SignalSourceAnalyzer SignalSource = "analyzer"
+	SignalSourceExternal SignalSource = "external"
+	SignalSourceManual   SignalSource = "manual"
+)
+
+// SignalTiming tags whether an Oscillator was scored against the
+// trading interval's confirmed closed candle or one still forming
+// intrabar, see settings.RequireClosedCandle.
+type SignalTiming string
+
+const (
+	SignalTimingClosed   SignalTiming = "closed"
+	SignalTimingIntrabar SignalTiming = "intrabar"
+)
+
 type Oscillator struct {
 	Symbol string            `json:"symbol"`
 	Stoch  map[string]*Stoch `json:"stoch"`
+	// EngineVersion identifies which scoring engine/parameter set produced
+	// this decision, so A/B performance can be compared in analytics.
+	EngineVersion string `json:"engine_version,omitempty"`
+	// Confidence is the fraction of configured intervals whose RSI agrees
+	// with the position side resolved from the trading interval, used to
+	// merge cross-interval agreement into a single boosted decision
+	// instead of one decision per interval.
+	Confidence float64 `json:"confidence,omitempty"`
+	// Regime tags the trading interval's market behavior (trending,
+	// ranging, volatile), see internal/regime.
+	Regime regime.Regime `json:"regime,omitempty"`
+	// Source identifies what produced this signal. Empty is treated as
+	// SignalSourceAnalyzer, the only producer before this field existed.
+	Source SignalSource `json:"source,omitempty"`
+	// Freshness is, per interval present in Stoch, the millisecond
+	// timestamp of the last candle that interval's RSI/K/D were computed
+	// from, see CandlesData.UpdateTime. An interval missing here means
+	// freshness is unknown for it.
+	Freshness map[string]int64 `json:"freshness,omitempty"`
+	// MissingIntervals lists intervals this signal expected data for but
+	// found none for on this tick. risk.IntervalConfluence passes them to
+	// CombineWeighted as absent inputs, so confluence is computed over the
+	// intervals that have data only; a missing interval counts neither as
+	// agreeing nor as disagreeing.
+	MissingIntervals []string `json:"missing_intervals,omitempty"`
+	// ATR is the trading interval's Average True Range at the time this
+	// signal was computed, see talib.ATR. Used by risk.ValidateStops to
+	// judge whether a stop-loss distance is sane relative to recent
+	// volatility instead of against a fixed price distance. Zero means
+	// unavailable (e.g. not enough candle history yet).
+	ATR float64 `json:"atr,omitempty"`
+	// Timing is whether the trading interval's tail candle was
+	// confirmed closed or still forming intrabar when this signal was
+	// computed, see settings.RequireClosedCandle. Empty is treated as
+	// SignalTimingIntrabar, the only behavior before this field existed.
+	Timing SignalTiming `json:"timing,omitempty"`
 }
 
 func (s *Oscillator) String() string {
@@ -0,0 +1,46 @@
+package regime
+
+import "math"
+
+// EstimateHurst estimates the Hurst exponent of a price series using a
+// simplified rescaled range (R/S) analysis over the full window: values
+// above 0.5 indicate a trending (persistent) series, values below 0.5
+// indicate a mean-reverting (anti-persistent) one. Returns 0.5 (random
+// walk) when there isn't enough data, or the data has no variance, to
+// estimate from.
+func EstimateHurst(closing []float64) float64 {
+	n := len(closing)
+	if n < 2 {
+		return 0.5
+	}
+
+	mean := 0.0
+	for _, value := range closing {
+		mean += value
+	}
+	mean /= float64(n)
+
+	var cumulative, sumSquares float64
+	minDeviation, maxDeviation := math.Inf(1), math.Inf(-1)
+
+	for _, value := range closing {
+		cumulative += value - mean
+		sumSquares += (value - mean) * (value - mean)
+
+		if cumulative < minDeviation {
+			minDeviation = cumulative
+		}
+		if cumulative > maxDeviation {
+			maxDeviation = cumulative
+		}
+	}
+
+	rescaledRange := maxDeviation - minDeviation
+	stddev := math.Sqrt(sumSquares / float64(n))
+
+	if stddev == 0 || rescaledRange == 0 {
+		return 0.5
+	}
+
+	return math.Log(rescaledRange/stddev) / math.Log(float64(n))
+}
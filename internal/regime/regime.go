@@ -0,0 +1,52 @@
+package regime
+
+// Regime classifies the current market behavior for a symbol, exposed to
+// the decision engine so it can switch between breakout-style and
+// mean-reversion scoring behavior.
+type Regime string
+
+const (
+	RegimeRanging  Regime = "ranging"
+	RegimeTrending Regime = "trending"
+	RegimeVolatile Regime = "volatile"
+)
+
+// Thresholds configures the boundaries Classify uses to tell trending,
+// ranging and volatile markets apart.
+type Thresholds struct {
+	// TrendingADX is the ADX level above which a market is considered
+	// trending rather than ranging.
+	TrendingADX float64
+	// VolatileBandWidth is the Bollinger Band width above which a market
+	// is considered volatile, regardless of trend strength.
+	VolatileBandWidth float64
+	// TrendingHurst is the Hurst exponent level above which a series is
+	// considered persistent (trending) rather than mean-reverting.
+	TrendingHurst float64
+}
+
+// DefaultThresholds mirrors widely used conventions: ADX above 25
+// indicates a trend, a band width above 10% of price indicates an
+// expanding, volatile range, and a Hurst exponent above 0.5 indicates a
+// persistent (trending) rather than mean-reverting series.
+var DefaultThresholds = Thresholds{
+	TrendingADX:       25,
+	VolatileBandWidth: 0.1,
+	TrendingHurst:     0.5,
+}
+
+// Classify tags a symbol's current regime from its latest ADX,
+// Bollinger Band width and Hurst exponent readings. Volatile takes
+// precedence over trending, since a trend that is also expanding
+// rapidly is safer traded as a breakout than scored as an established
+// trend. A strong ADX reading is only trusted as trending when the
+// Hurst exponent agrees the series is persistent, not mean-reverting.
+func Classify(thresholds Thresholds, adx, bandWidth, hurst float64) Regime {
+	if bandWidth >= thresholds.VolatileBandWidth {
+		return RegimeVolatile
+	}
+	if adx >= thresholds.TrendingADX && hurst >= thresholds.TrendingHurst {
+		return RegimeTrending
+	}
+	return RegimeRanging
+}
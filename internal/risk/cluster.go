@@ -0,0 +1,100 @@
+package risk
+
+import (
+	"sync"
+	"time"
+)
+
+// ClusterConfig groups symbols whose price moves are highly correlated
+// (e.g. majors like BTCUSDT/ETHUSDT/SOLUSDT), so entries on more than
+// one of them within WindowMinutes of each other are sized as one
+// combined exposure instead of independently, see ClusterTracker.
+type ClusterConfig struct {
+	Groups        map[string][]string
+	WindowMinutes int
+}
+
+// ClusterTracker tracks recent entry timestamps per correlation group
+// and reports the size multiplier a new entry on a clustered symbol
+// should apply.
+type ClusterTracker struct {
+	cfg         ClusterConfig
+	symbolGroup map[string]string
+	mux         sync.Mutex
+	recent      map[string][]time.Time
+}
+
+// NewClusterTracker returns a ClusterTracker enforcing cfg.
+func NewClusterTracker(cfg ClusterConfig) *ClusterTracker {
+	symbolGroup := make(map[string]string)
+	for group, symbols := range cfg.Groups {
+		for _, symbol := range symbols {
+			symbolGroup[symbol] = group
+		}
+	}
+
+	return &ClusterTracker{
+		cfg:         cfg,
+		symbolGroup: symbolGroup,
+		recent:      make(map[string][]time.Time),
+	}
+}
+
+// SizeMultiple previews the factor, in (0, 1], a new entry on symbol
+// should scale its size by: 1 / n, where n is the number of entries
+// this one would make within symbol's correlation group within
+// WindowMinutes (i.e. the entries already recorded, plus this
+// hypothetical one). A symbol with no configured group, or
+// WindowMinutes <= 0, is never clustered and always returns 1.
+//
+// It does not record anything itself -- a caller that sizes a position
+// off this multiple still has to run its own downstream checks before
+// the position is actually opened, so call Commit once it is.
+// Recording on SizeMultiple would skew the window against a signal
+// that never actually traded.
+func (c *ClusterTracker) SizeMultiple(symbol string) float64 {
+	group, ok := c.symbolGroup[symbol]
+	if !ok || c.cfg.WindowMinutes <= 0 {
+		return 1
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return 1 / float64(len(c.pruneLocked(group))+1)
+}
+
+// Commit records that an entry on symbol was actually opened, so later
+// SizeMultiple/Commit calls against its correlation group see it
+// within WindowMinutes. Call this once the position has cleared every
+// downstream check SizeMultiple doesn't know about, not when
+// SizeMultiple is called.
+func (c *ClusterTracker) Commit(symbol string) {
+	group, ok := c.symbolGroup[symbol]
+	if !ok || c.cfg.WindowMinutes <= 0 {
+		return
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.recent[group] = append(c.pruneLocked(group), time.Now())
+}
+
+// pruneLocked drops group's timestamps older than WindowMinutes and
+// stores the result back, so repeated calls don't re-walk stale
+// entries. Callers must hold c.mux.
+func (c *ClusterTracker) pruneLocked(group string) []time.Time {
+	now := time.Now()
+	window := time.Duration(c.cfg.WindowMinutes) * time.Minute
+
+	kept := c.recent[group][:0]
+	for _, ts := range c.recent[group] {
+		if now.Sub(ts) <= window {
+			kept = append(kept, ts)
+		}
+	}
+
+	c.recent[group] = kept
+	return kept
+}
@@ -0,0 +1,55 @@
+package risk
+
+import "testing"
+
+func TestClusterTrackerSizeMultiple(t *testing.T) {
+	tracker := NewClusterTracker(ClusterConfig{
+		Groups:        map[string][]string{"majors": {"BTCUSDT", "ETHUSDT", "SOLUSDT"}},
+		WindowMinutes: 10,
+	})
+
+	if got := tracker.SizeMultiple("BTCUSDT"); got != 1 {
+		t.Errorf("first entry in cluster: got multiple %v, want 1", got)
+	}
+	tracker.Commit("BTCUSDT")
+
+	if got := tracker.SizeMultiple("ETHUSDT"); got != 0.5 {
+		t.Errorf("second entry in cluster within window: got multiple %v, want 0.5", got)
+	}
+	tracker.Commit("ETHUSDT")
+
+	if got := tracker.SizeMultiple("SOLUSDT"); got != 1.0/3 {
+		t.Errorf("third entry in cluster within window: got multiple %v, want %v", got, 1.0/3)
+	}
+	tracker.Commit("SOLUSDT")
+
+	if got := tracker.SizeMultiple("ADAUSDT"); got != 1 {
+		t.Errorf("symbol outside every group: got multiple %v, want 1", got)
+	}
+}
+
+func TestClusterTrackerSizeMultipleWithoutCommitDoesNotRecord(t *testing.T) {
+	tracker := NewClusterTracker(ClusterConfig{
+		Groups:        map[string][]string{"majors": {"BTCUSDT", "ETHUSDT"}},
+		WindowMinutes: 10,
+	})
+
+	tracker.SizeMultiple("BTCUSDT")
+
+	if got := tracker.SizeMultiple("ETHUSDT"); got != 1 {
+		t.Errorf("previewing without committing should not skew later previews: got multiple %v, want 1", got)
+	}
+}
+
+func TestClusterTrackerDisabled(t *testing.T) {
+	tracker := NewClusterTracker(ClusterConfig{
+		Groups: map[string][]string{"majors": {"BTCUSDT", "ETHUSDT"}},
+	})
+
+	tracker.SizeMultiple("BTCUSDT")
+	tracker.Commit("BTCUSDT")
+
+	if got := tracker.SizeMultiple("ETHUSDT"); got != 1 {
+		t.Errorf("WindowMinutes <= 0 should disable clustering: got multiple %v, want 1", got)
+	}
+}
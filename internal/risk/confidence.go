@@ -0,0 +1,35 @@
+package risk
+
+import "github.com/anvh2/futures-trading/internal/models"
+
+const (
+	// minWinRateSample is the minimum number of closed trades before
+	// recent win rate is trusted to adjust confidence. Below this the
+	// raw confidence score is returned unchanged.
+	minWinRateSample = 10
+	// baselineWinRate is the win rate around which confidence is
+	// neither boosted nor penalized.
+	baselineWinRate = 0.5
+)
+
+// AdjustConfidence scales a raw confluence confidence score by recent
+// win rate, so a strategy/symbol on a cold streak needs a stronger raw
+// signal to clear the same entry confidence threshold as one that's
+// been performing well. history may be nil or too short, in which case
+// confidence is returned unchanged.
+func AdjustConfidence(confidence float64, history *models.TradingHistory) float64 {
+	if history == nil || len(history.Results()) < minWinRateSample {
+		return confidence
+	}
+
+	adjusted := confidence * (history.WinRate() / baselineWinRate)
+
+	switch {
+	case adjusted > 1:
+		return 1
+	case adjusted < 0:
+		return 0
+	default:
+		return adjusted
+	}
+}
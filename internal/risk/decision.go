@@ -0,0 +1,105 @@
+package risk
+
+import (
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+// DecisionParams are the subset of Settings a decision depends on,
+// passed explicitly rather than as a *settings.Settings so ComputeDecision
+// has no dependency on the settings package and can be replayed against
+// arbitrary alternative values.
+type DecisionParams struct {
+	TradingInterval             string
+	RequiredConfluenceIntervals []string
+}
+
+// DecisionInput is everything ComputeDecision needs to re-derive a
+// decision, captured once at analysis time so it can be replayed later
+// against alternative DecisionParams. See Analyzer.process, which logs
+// one of these under the "decision.computed" event on every signal it
+// resolves, and cmd/decision.go, which replays a log of them.
+type DecisionInput struct {
+	Symbol     string                 `json:"symbol"`
+	Oscillator *models.Oscillator     `json:"oscillator"`
+	History    *models.TradingHistory `json:"history,omitempty"`
+}
+
+// Decision is ComputeDecision's resolved output.
+type Decision struct {
+	Side       string  `json:"side"`
+	Confidence float64 `json:"confidence"`
+	// Confluent reports whether every one of DecisionParams'
+	// RequiredConfluenceIntervals agreed with Side. A decision that
+	// fails this would have been rejected by Analyzer.process before
+	// ever reaching the queue.
+	Confluent bool `json:"confluent"`
+}
+
+// IntervalConfluence returns the fraction of intervals in oscillator
+// whose resolved position side agrees with the one resolved from
+// tradingInterval, so a signal confirmed across multiple timeframes
+// reports higher confidence than one seen on a single interval alone.
+// Each interval in oscillator.MissingIntervals contributes a present:false
+// WeightedInput, so CombineWeighted renormalizes over the intervals
+// that actually have data instead of treating the missing ones as
+// silently agreeing (or, worse, as a zero RSI that happens to resolve
+// to a real side).
+func IntervalConfluence(oscillator *models.Oscillator, tradingInterval string) float64 {
+	tradingSide := helpers.ResolvePositionSide(oscillator.GetRSI(tradingInterval))
+
+	inputs := make([]WeightedInput, 0, len(oscillator.Stoch)+len(oscillator.MissingIntervals))
+
+	for interval, stoch := range oscillator.Stoch {
+		agree := 0.0
+		if helpers.ResolvePositionSide(stoch.RSI) == tradingSide {
+			agree = 1
+		}
+
+		inputs = append(inputs, WeightedInput{Name: interval, Value: agree, Weight: 1, Present: true})
+	}
+
+	for _, interval := range oscillator.MissingIntervals {
+		inputs = append(inputs, WeightedInput{Name: interval, Weight: 1, Present: false})
+	}
+
+	return CombineWeighted(inputs)
+}
+
+// HasRequiredConfluence reports whether every interval in required
+// resolves to the same position side as tradingInterval. It fails
+// closed: an interval missing from oscillator.Stoch counts as
+// disagreement, so a gate can never be satisfied by data that hasn't
+// arrived yet. An empty required list always passes (gate disabled).
+func HasRequiredConfluence(oscillator *models.Oscillator, tradingInterval string, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+
+	tradingSide := helpers.ResolvePositionSide(oscillator.GetRSI(tradingInterval))
+
+	for _, interval := range required {
+		stoch, ok := oscillator.Stoch[interval]
+		if !ok || helpers.ResolvePositionSide(stoch.RSI) != tradingSide {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ComputeDecision resolves input's side and confidence the same way
+// Analyzer.process does in production, parameterized by params instead
+// of a live settings.Settings so it can be run offline against
+// alternative DecisionParams to evaluate a parameter change (how many
+// holds would become longs, etc.) before it ships.
+func ComputeDecision(input *DecisionInput, params DecisionParams) *Decision {
+	side := helpers.ResolvePositionSide(input.Oscillator.GetRSI(params.TradingInterval))
+	confluence := IntervalConfluence(input.Oscillator, params.TradingInterval)
+
+	return &Decision{
+		Side:       side,
+		Confidence: AdjustConfidence(confluence, input.History),
+		Confluent:  HasRequiredConfluence(input.Oscillator, params.TradingInterval, params.RequiredConfluenceIntervals),
+	}
+}
@@ -0,0 +1,134 @@
+package risk
+
+import (
+	"sort"
+	"time"
+)
+
+// maxEquitySamples bounds how many observations EquityCurve.Samples
+// keeps, so a long-running process doesn't grow its persisted state
+// file without bound. At one sample per
+// settings.EquityTrackingIntervalSeconds (default 60s), this holds a
+// little over 2 days of history.
+const maxEquitySamples = 3000
+
+// EquitySample is one account-equity (realized + unrealized PNL)
+// observation, see EquityCurve.Samples.
+type EquitySample struct {
+	Timestamp int64   `json:"timestamp,omitempty"`
+	Equity    float64 `json:"equity,omitempty"`
+}
+
+// EquityCurve tracks an account's peak and current equity so position
+// sizing can scale down as drawdown deepens and scale back up as the
+// account recovers, instead of risking a fixed size through a losing
+// streak. It also keeps a bounded time series of every observation
+// (Samples), so a caller needing more than the latest point — a VaR
+// computation or an equity-curve chart — doesn't have to be fed from a
+// separate system.
+type EquityCurve struct {
+	Peak    float64 `json:"peak,omitempty"`
+	Current float64 `json:"current,omitempty"`
+	// Samples is Update's observation history, oldest first, capped at
+	// maxEquitySamples.
+	Samples []EquitySample `json:"samples,omitempty"`
+}
+
+// Update records a new equity observation, raising Peak if a new high
+// was reached, and appends it to Samples.
+func (e *EquityCurve) Update(equity float64) {
+	if equity > e.Peak {
+		e.Peak = equity
+	}
+	e.Current = equity
+
+	e.Samples = append(e.Samples, EquitySample{Timestamp: time.Now().UnixMilli(), Equity: equity})
+	if len(e.Samples) > maxEquitySamples {
+		e.Samples = e.Samples[len(e.Samples)-maxEquitySamples:]
+	}
+}
+
+// HistoricalVaR estimates the historical-simulation Value at Risk of
+// the account over horizon, as a positive fraction of equity expected
+// to be lost at confidence (e.g. 0.95 for a 95% VaR). It buckets
+// Samples into horizon-sized periods, takes the fractional change
+// across each, and returns the loss at the (1-confidence) percentile
+// of that distribution. Fewer than two periods of history returns 0.
+func (e *EquityCurve) HistoricalVaR(confidence float64, horizon time.Duration) float64 {
+	periods := e.periodReturns(horizon)
+	if len(periods) < 2 {
+		return 0
+	}
+
+	sort.Float64s(periods)
+
+	rank := int((1 - confidence) * float64(len(periods)))
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(periods) {
+		rank = len(periods) - 1
+	}
+
+	if loss := -periods[rank]; loss > 0 {
+		return loss
+	}
+
+	return 0
+}
+
+// periodReturns buckets Samples into horizon-sized windows by
+// Timestamp and returns, for each window a later sample closes, the
+// fractional equity change from the previous window's last sample (the
+// first sample, for the first window) to the window's own last sample.
+// The final, still-open window is not included.
+func (e *EquityCurve) periodReturns(horizon time.Duration) []float64 {
+	if horizon <= 0 || len(e.Samples) < 2 {
+		return nil
+	}
+
+	var returns []float64
+
+	windowStart := e.Samples[0].Timestamp
+	periodOpen := e.Samples[0].Equity
+	last := e.Samples[0].Equity
+
+	for _, sample := range e.Samples[1:] {
+		if time.Duration(sample.Timestamp-windowStart)*time.Millisecond >= horizon {
+			if periodOpen > 0 {
+				returns = append(returns, (last-periodOpen)/periodOpen)
+			}
+			windowStart = sample.Timestamp
+			periodOpen = last
+		}
+		last = sample.Equity
+	}
+
+	return returns
+}
+
+// DrawdownPercent returns how far Current sits below Peak, as a
+// percentage. It is 0 once Current reaches a new peak.
+func (e *EquityCurve) DrawdownPercent() float64 {
+	if e.Peak <= 0 || e.Current >= e.Peak {
+		return 0
+	}
+	return (e.Peak - e.Current) / e.Peak * 100
+}
+
+// SizeMultiplier scales linearly from 1 at zero drawdown down to 0 at
+// maxDrawdownPercent, so position size deleverages in proportion to how
+// close the account is to its configured max drawdown, then recovers
+// the same way as equity climbs back toward Peak.
+func (e *EquityCurve) SizeMultiplier(maxDrawdownPercent float64) float64 {
+	if maxDrawdownPercent <= 0 {
+		return 1
+	}
+
+	drawdown := e.DrawdownPercent()
+	if drawdown >= maxDrawdownPercent {
+		return 0
+	}
+
+	return 1 - drawdown/maxDrawdownPercent
+}
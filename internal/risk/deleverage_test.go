@@ -0,0 +1,67 @@
+package risk
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEquityCurveUpdateTracksPeakAndSamples(t *testing.T) {
+	curve := &EquityCurve{}
+
+	curve.Update(100)
+	curve.Update(120)
+	curve.Update(90)
+
+	if curve.Peak != 120 {
+		t.Fatalf("expected peak 120, got %v", curve.Peak)
+	}
+	if curve.Current != 90 {
+		t.Fatalf("expected current 90, got %v", curve.Current)
+	}
+	if len(curve.Samples) != 3 {
+		t.Fatalf("expected 3 samples, got %d", len(curve.Samples))
+	}
+}
+
+func TestEquityCurveUpdateCapsSamples(t *testing.T) {
+	curve := &EquityCurve{}
+
+	for i := 0; i < maxEquitySamples+10; i++ {
+		curve.Update(float64(i))
+	}
+
+	if len(curve.Samples) != maxEquitySamples {
+		t.Fatalf("expected %d samples, got %d", maxEquitySamples, len(curve.Samples))
+	}
+	if curve.Samples[0].Equity != 10 {
+		t.Fatalf("expected oldest sample to have been evicted, got equity %v", curve.Samples[0].Equity)
+	}
+}
+
+func TestEquityCurveHistoricalVaR(t *testing.T) {
+	curve := &EquityCurve{}
+
+	base := time.Now().Add(-time.Hour)
+	equities := []float64{100, 110, 95, 105, 80, 120}
+
+	for i, equity := range equities {
+		curve.Samples = append(curve.Samples, EquitySample{
+			Timestamp: base.Add(time.Duration(i) * time.Minute).UnixMilli(),
+			Equity:    equity,
+		})
+	}
+
+	var_ := curve.HistoricalVaR(0.8, time.Minute)
+	if var_ <= 0 {
+		t.Fatalf("expected a positive VaR given a drawdown period in the sample history, got %v", var_)
+	}
+}
+
+func TestEquityCurveHistoricalVaRNeedsHistory(t *testing.T) {
+	curve := &EquityCurve{}
+	curve.Update(100)
+
+	if got := curve.HistoricalVaR(0.95, time.Minute); got != 0 {
+		t.Fatalf("expected 0 VaR with fewer than two periods of history, got %v", got)
+	}
+}
@@ -0,0 +1,32 @@
+package risk
+
+import "time"
+
+// FundingWindowConfig bounds how close to a funding settlement a new
+// position may be opened when that settlement would be paid away from
+// the side being entered.
+type FundingWindowConfig struct {
+	// AvoidBefore is how long before the next funding settlement entries
+	// are delayed, when the current funding rate would be paid against
+	// the side being opened. Zero disables the check.
+	AvoidBefore time.Duration
+}
+
+// InFundingAvoidanceWindow reports whether opening positionSide ("LONG"
+// or "SHORT") right now would pay away funding at nextFundingTime, and
+// that settlement falls within cfg.AvoidBefore of now.
+//
+// Binance pays funding from longs to shorts when fundingRate is
+// positive, and from shorts to longs when it's negative.
+func InFundingAvoidanceWindow(cfg FundingWindowConfig, positionSide string, fundingRate float64, nextFundingTime, now time.Time) bool {
+	if cfg.AvoidBefore <= 0 {
+		return false
+	}
+
+	paysAway := (positionSide == "LONG" && fundingRate > 0) || (positionSide == "SHORT" && fundingRate < 0)
+	if !paysAway {
+		return false
+	}
+
+	return now.Before(nextFundingTime) && nextFundingTime.Sub(now) <= cfg.AvoidBefore
+}
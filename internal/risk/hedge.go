@@ -0,0 +1,47 @@
+package risk
+
+import "fmt"
+
+// HedgeProposal describes an offsetting position ProposeHedge
+// recommends opening on a correlated instrument in response to an
+// extreme volatility violation, instead of market-closing the original
+// position into a flash crash.
+type HedgeProposal struct {
+	Symbol         string  `json:"symbol"`
+	HedgeSymbol    string  `json:"hedge_symbol"`
+	Side           string  `json:"side"`
+	SizeMultiplier float64 `json:"size_multiplier"`
+	Reason         string  `json:"reason"`
+}
+
+// ProposeHedge proposes an offsetting hedge for a positionSide position
+// on symbol, opened on its correlated instrument (correlated[symbol]),
+// when regime is extreme. Returns nil when the violation isn't severe
+// enough to hedge or no correlated instrument is configured for symbol.
+//
+// The hedge side assumes symbol and its correlated instrument move
+// together (positive correlation): a LONG position is hedged with a
+// SHORT on the correlated instrument and vice versa.
+func ProposeHedge(symbol, positionSide string, regime VolatilityRegime, correlated map[string]string) *HedgeProposal {
+	if regime != VolatilityRegimeExtreme {
+		return nil
+	}
+
+	hedgeSymbol, ok := correlated[symbol]
+	if !ok || hedgeSymbol == "" {
+		return nil
+	}
+
+	hedgeSide := "SHORT"
+	if positionSide == "SHORT" {
+		hedgeSide = "LONG"
+	}
+
+	return &HedgeProposal{
+		Symbol:         symbol,
+		HedgeSymbol:    hedgeSymbol,
+		Side:           hedgeSide,
+		SizeMultiplier: 1.0,
+		Reason:         fmt.Sprintf("extreme volatility on %s, hedging via %s %s instead of flattening into a flash crash", symbol, hedgeSide, hedgeSymbol),
+	}
+}
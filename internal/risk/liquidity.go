@@ -0,0 +1,39 @@
+package risk
+
+// LiquidityConfig bounds the spread and top-of-book depth an entry is
+// allowed to trade into.
+type LiquidityConfig struct {
+	// MaxSpreadBps rejects an entry when the bid/ask spread exceeds this
+	// many basis points of the mid price. 0 disables the check.
+	MaxSpreadBps float64
+	// MinBookSizeRatio rejects an entry when the top-of-book size on the
+	// side being taken is smaller than the order quantity times this
+	// ratio, i.e. the book can't reasonably absorb the order. 0 disables
+	// the check.
+	MinBookSizeRatio float64
+}
+
+// SpreadBps returns the bid/ask spread as basis points of the mid price.
+func SpreadBps(bidPrice, askPrice float64) float64 {
+	mid := (bidPrice + askPrice) / 2
+	if mid <= 0 {
+		return 0
+	}
+
+	return (askPrice - bidPrice) / mid * 10000
+}
+
+// PassesLiquidityFilter reports whether an order of quantity on the
+// given side (bidSize/askSize matching the side being taken) clears
+// cfg's spread and depth thresholds.
+func PassesLiquidityFilter(cfg LiquidityConfig, bidPrice, askPrice, topOfBookSize, quantity float64) bool {
+	if cfg.MaxSpreadBps > 0 && SpreadBps(bidPrice, askPrice) > cfg.MaxSpreadBps {
+		return false
+	}
+
+	if cfg.MinBookSizeRatio > 0 && quantity > 0 && topOfBookSize < quantity*cfg.MinBookSizeRatio {
+		return false
+	}
+
+	return true
+}
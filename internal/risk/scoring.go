@@ -0,0 +1,39 @@
+package risk
+
+// WeightedInput is one field contributing to a composite score, paired
+// with the weight it carries when present. Present tracks whether data
+// for this field was actually available this tick, so a caller can
+// build the list directly from raw inputs (e.g. one entry per
+// interval, Present set from whether it's in oscillator.Stoch) instead
+// of having to pre-filter missing fields itself.
+type WeightedInput struct {
+	Name    string
+	Value   float64
+	Weight  float64
+	Present bool
+}
+
+// CombineWeighted returns the weighted average of inputs' Value,
+// skipping any with Present false and renormalizing the remaining
+// Weight over only what's left, so a field missing its data is
+// excluded from the score entirely instead of defaulting Value to zero
+// and being scored as a real (and often misleadingly neutral or
+// extreme) measurement. Returns 0 if nothing is present.
+func CombineWeighted(inputs []WeightedInput) float64 {
+	var sum, totalWeight float64
+
+	for _, in := range inputs {
+		if !in.Present {
+			continue
+		}
+
+		sum += in.Value * in.Weight
+		totalWeight += in.Weight
+	}
+
+	if totalWeight == 0 {
+		return 0
+	}
+
+	return sum / totalWeight
+}
@@ -0,0 +1,167 @@
+package risk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+// maxRealHistory caps how many real closed trades ShadowTracker keeps
+// around to compare shadow decisions against, so a long-running process
+// doesn't grow this unbounded.
+const maxRealHistory = 200
+
+// ShadowDecision records a decision that was scored but never executed,
+// typically because a risk rule rejected it, together with the forward
+// return it would have realized had it been taken.
+type ShadowDecision struct {
+	Symbol        string  `json:"symbol,omitempty"`
+	RejectReason  string  `json:"reject_reason,omitempty"`
+	EntryPrice    float64 `json:"entry_price,omitempty"`
+	ForwardPrice  float64 `json:"forward_price,omitempty"`
+	ForwardReturn float64 `json:"forward_return,omitempty"`
+}
+
+// OpportunityCostReport summarizes how shadow (rejected) decisions would
+// have performed against the real trades that were actually executed,
+// so the cost of risk-engine rejections can be quantified.
+type OpportunityCostReport struct {
+	ShadowCount     int     `json:"shadow_count,omitempty"`
+	ShadowAvgReturn float64 `json:"shadow_avg_return,omitempty"`
+	RealCount       int     `json:"real_count,omitempty"`
+	RealAvgReturn   float64 `json:"real_avg_return,omitempty"`
+	OpportunityCost float64 `json:"opportunity_cost,omitempty"`
+}
+
+// CompareShadowToReal aggregates shadow decisions against closed real
+// trades and reports the average return gap between them. A positive
+// OpportunityCost means rejected signals would, on average, have
+// outperformed the trades that were actually taken.
+func CompareShadowToReal(shadows []*ShadowDecision, real []*models.TradeResult) *OpportunityCostReport {
+	report := &OpportunityCostReport{
+		ShadowCount: len(shadows),
+		RealCount:   len(real),
+	}
+
+	var shadowSum float64
+	for _, s := range shadows {
+		shadowSum += s.ForwardReturn
+	}
+	if report.ShadowCount > 0 {
+		report.ShadowAvgReturn = shadowSum / float64(report.ShadowCount)
+	}
+
+	var realSum float64
+	for _, r := range real {
+		realSum += r.PNL
+	}
+	if report.RealCount > 0 {
+		report.RealAvgReturn = realSum / float64(report.RealCount)
+	}
+
+	report.OpportunityCost = report.ShadowAvgReturn - report.RealAvgReturn
+	return report
+}
+
+// pendingShadow is a rejected decision awaiting enough elapsed time to
+// be scored against how price actually moved, see ShadowTracker.Record/
+// Score.
+type pendingShadow struct {
+	symbol       string
+	rejectReason string
+	entryPrice   float64
+	rejectedAt   time.Time
+}
+
+// ShadowTracker records decisions rejected by the risk engine and real
+// closed trades over time, so CompareShadowToReal always has current
+// inputs to work from instead of requiring a caller to assemble both
+// slices by hand.
+type ShadowTracker struct {
+	mux     sync.Mutex
+	pending []*pendingShadow
+	scored  []*ShadowDecision
+	real    []*models.TradeResult
+}
+
+// NewShadowTracker returns an empty ShadowTracker.
+func NewShadowTracker() *ShadowTracker {
+	return &ShadowTracker{}
+}
+
+// Record notes that symbol was rejected for reason while it would have
+// entered at entryPrice, a candidate for scoring once Score runs after
+// enough time has passed to judge it by.
+func (t *ShadowTracker) Record(symbol, reason string, entryPrice float64) {
+	if entryPrice <= 0 {
+		return
+	}
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.pending = append(t.pending, &pendingShadow{
+		symbol:       symbol,
+		rejectReason: reason,
+		entryPrice:   entryPrice,
+		rejectedAt:   time.Now(),
+	})
+}
+
+// RecordReal appends result to the tracker's recent real trade history,
+// trimmed to maxRealHistory, so Report always compares shadow decisions
+// against current performance rather than every trade ever closed.
+func (t *ShadowTracker) RecordReal(result *models.TradeResult) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.real = append(t.real, result)
+	if len(t.real) > maxRealHistory {
+		t.real = t.real[len(t.real)-maxRealHistory:]
+	}
+}
+
+// Score resolves every pending decision rejected at least forwardWindow
+// ago, looking up its forward price via currentPrice (returns 0 to skip
+// a symbol that can't be priced right now) and moving it from pending
+// to scored. Decisions not yet forwardWindow old are left pending for a
+// later call.
+func (t *ShadowTracker) Score(forwardWindow time.Duration, currentPrice func(symbol string) float64) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	now := time.Now()
+	remaining := t.pending[:0]
+
+	for _, p := range t.pending {
+		if now.Sub(p.rejectedAt) < forwardWindow {
+			remaining = append(remaining, p)
+			continue
+		}
+
+		forwardPrice := currentPrice(p.symbol)
+		if forwardPrice <= 0 {
+			continue
+		}
+
+		t.scored = append(t.scored, &ShadowDecision{
+			Symbol:        p.symbol,
+			RejectReason:  p.rejectReason,
+			EntryPrice:    p.entryPrice,
+			ForwardPrice:  forwardPrice,
+			ForwardReturn: (forwardPrice - p.entryPrice) / p.entryPrice,
+		})
+	}
+
+	t.pending = remaining
+}
+
+// Report compares every decision Score has resolved so far against the
+// tracker's recent real trades, see CompareShadowToReal.
+func (t *ShadowTracker) Report() *OpportunityCostReport {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	return CompareShadowToReal(t.scored, t.real)
+}
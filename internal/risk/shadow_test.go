@@ -0,0 +1,73 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+func TestCompareShadowToReal(t *testing.T) {
+	shadows := []*ShadowDecision{
+		{Symbol: "BTCUSDT", ForwardReturn: 0.02},
+		{Symbol: "ETHUSDT", ForwardReturn: 0.04},
+	}
+	real := []*models.TradeResult{
+		{Symbol: "BTCUSDT", PNL: 10},
+		{Symbol: "SOLUSDT", PNL: 20},
+	}
+
+	report := CompareShadowToReal(shadows, real)
+
+	if report.ShadowCount != 2 || report.ShadowAvgReturn != 0.03 {
+		t.Errorf("shadow side = count %d avg %v, want count 2 avg 0.03", report.ShadowCount, report.ShadowAvgReturn)
+	}
+	if report.RealCount != 2 || report.RealAvgReturn != 15 {
+		t.Errorf("real side = count %d avg %v, want count 2 avg 15", report.RealCount, report.RealAvgReturn)
+	}
+	if report.OpportunityCost != report.ShadowAvgReturn-report.RealAvgReturn {
+		t.Errorf("OpportunityCost = %v, want %v", report.OpportunityCost, report.ShadowAvgReturn-report.RealAvgReturn)
+	}
+}
+
+func TestShadowTrackerScoreWaitsForForwardWindow(t *testing.T) {
+	tracker := NewShadowTracker()
+	tracker.Record("BTCUSDT", "low_liquidity", 100)
+
+	calls := 0
+	tracker.Score(time.Hour, func(symbol string) float64 {
+		calls++
+		return 110
+	})
+
+	if calls != 0 {
+		t.Errorf("Score looked up a price before forwardWindow elapsed, calls = %d", calls)
+	}
+	if report := tracker.Report(); report.ShadowCount != 0 {
+		t.Errorf("ShadowCount = %d before forwardWindow elapsed, want 0", report.ShadowCount)
+	}
+
+	tracker.Score(0, func(symbol string) float64 {
+		calls++
+		return 110
+	})
+
+	report := tracker.Report()
+	if report.ShadowCount != 1 {
+		t.Fatalf("ShadowCount = %d after Score, want 1", report.ShadowCount)
+	}
+	if report.ShadowAvgReturn != 0.1 {
+		t.Errorf("ShadowAvgReturn = %v, want 0.1", report.ShadowAvgReturn)
+	}
+}
+
+func TestShadowTrackerRecordIgnoresNonPositiveEntryPrice(t *testing.T) {
+	tracker := NewShadowTracker()
+	tracker.Record("BTCUSDT", "low_liquidity", 0)
+
+	tracker.Score(0, func(symbol string) float64 { return 110 })
+
+	if report := tracker.Report(); report.ShadowCount != 0 {
+		t.Errorf("ShadowCount = %d, want 0 for a rejection with no entry price", report.ShadowCount)
+	}
+}
@@ -0,0 +1,100 @@
+package risk
+
+import (
+	"errors"
+
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+// SizingMode selects which algorithm is used to turn an entry price and
+// the account's trading cost into an order quantity.
+type SizingMode byte
+
+const (
+	SizingModeConfidence SizingMode = iota
+	SizingModeKelly
+)
+
+const (
+	// defaultKellyFraction caps the Kelly stake at a fraction of the
+	// theoretical full-Kelly size, to keep sizing conservative.
+	defaultKellyFraction = 0.5
+	// maxKellyFraction never risks more than this fraction of trading
+	// cost on a single position, regardless of win rate/edge.
+	maxKellyFraction = 0.2
+	// minTradesForKelly is the minimum sample size before the Kelly
+	// sizer trusts rolling stats instead of falling back to confidence.
+	minTradesForKelly = 20
+)
+
+var errNotEnoughHistory = errors.New("risk: not enough trading history for kelly sizing")
+
+// Sizer computes the quantity (in quote asset amount) to risk on a new
+// position given the account's configured trading cost and confidence.
+type Sizer interface {
+	Size(tradingCost float64, confidence float64) (float64, error)
+}
+
+// NewSizer returns the Sizer selected by mode. history may be nil for
+// SizingModeConfidence.
+func NewSizer(mode SizingMode, history *models.TradingHistory) Sizer {
+	switch mode {
+	case SizingModeKelly:
+		return &KellySizer{history: history, kellyFraction: defaultKellyFraction}
+	default:
+		return &ConfidenceSizer{}
+	}
+}
+
+// ConfidenceSizer scales the configured trading cost by a [0, 1]
+// confidence score, e.g. derived from how far an oscillator sits inside
+// its ready-to-trade range.
+type ConfidenceSizer struct{}
+
+func (s *ConfidenceSizer) Size(tradingCost float64, confidence float64) (float64, error) {
+	if confidence < 0 {
+		confidence = 0
+	}
+	if confidence > 1 {
+		confidence = 1
+	}
+
+	return tradingCost * confidence, nil
+}
+
+// KellySizer computes a capped fractional-Kelly stake from the rolling
+// win rate and average win/loss stored in TradingHistory. It falls back
+// to the full trading cost when there isn't enough history yet.
+type KellySizer struct {
+	history       *models.TradingHistory
+	kellyFraction float64
+}
+
+func (s *KellySizer) Size(tradingCost float64, confidence float64) (float64, error) {
+	if s.history == nil || len(s.history.Results()) < minTradesForKelly {
+		return tradingCost, errNotEnoughHistory
+	}
+
+	winRate := s.history.WinRate()
+	avgWin, avgLoss := s.history.AvgWinLoss()
+
+	if avgLoss <= 0 {
+		return tradingCost, nil
+	}
+
+	payoffRatio := avgWin / avgLoss
+	lossRate := 1 - winRate
+
+	// full Kelly fraction: f* = p - q/b
+	kelly := winRate - lossRate/payoffRatio
+	if kelly <= 0 {
+		return 0, nil
+	}
+
+	fraction := kelly * s.kellyFraction
+	if fraction > maxKellyFraction {
+		fraction = maxKellyFraction
+	}
+
+	return tradingCost * fraction, nil
+}
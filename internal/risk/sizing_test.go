@@ -0,0 +1,105 @@
+package risk
+
+import (
+	"testing"
+
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+func TestConfidenceSizerClampsConfidence(t *testing.T) {
+	sizer := &ConfidenceSizer{}
+
+	tests := []struct {
+		name       string
+		confidence float64
+		want       float64
+	}{
+		{"below zero clamps to zero", -0.5, 0},
+		{"above one clamps to one", 1.5, 100},
+		{"within range passes through", 0.4, 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sizer.Size(100, tt.confidence)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// historyOf builds a TradingHistory of n wins averaging winPNL and n
+// losses averaging -lossPNL, so tests can dial in an exact win rate and
+// payoff ratio without depending on TradeResult's other fields.
+func historyOf(wins, losses int, winPNL, lossPNL float64) *models.TradingHistory {
+	history := models.NewTradingHistory(wins + losses)
+
+	for i := 0; i < wins; i++ {
+		history.Add(&models.TradeResult{Win: true, PNL: winPNL})
+	}
+	for i := 0; i < losses; i++ {
+		history.Add(&models.TradeResult{Win: false, PNL: -lossPNL})
+	}
+
+	return history
+}
+
+func TestKellySizerFallsBackBelowMinTrades(t *testing.T) {
+	history := historyOf(minTradesForKelly/2, 0, 10, 10)
+	sizer := &KellySizer{history: history, kellyFraction: defaultKellyFraction}
+
+	got, err := sizer.Size(100, 1)
+	if err != errNotEnoughHistory {
+		t.Fatalf("got err %v, want errNotEnoughHistory", err)
+	}
+	if got != 100 {
+		t.Errorf("got %v, want unscaled trading cost 100", got)
+	}
+}
+
+func TestKellySizerFallsBackWhenNoLosses(t *testing.T) {
+	history := historyOf(minTradesForKelly, 0, 10, 0)
+	sizer := &KellySizer{history: history, kellyFraction: defaultKellyFraction}
+
+	got, err := sizer.Size(100, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 100 {
+		t.Errorf("got %v, want unscaled trading cost 100 when avgLoss <= 0", got)
+	}
+}
+
+func TestKellySizerReturnsZeroForNegativeEdge(t *testing.T) {
+	wins := 6
+	losses := 14
+	history := historyOf(wins, losses, 10, 10)
+	sizer := &KellySizer{history: history, kellyFraction: defaultKellyFraction}
+
+	got, err := sizer.Size(100, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 0 {
+		t.Errorf("got %v, want 0 for a negative Kelly edge", got)
+	}
+}
+
+func TestKellySizerCapsAtMaxKellyFraction(t *testing.T) {
+	wins := 18
+	losses := 2
+	history := historyOf(wins, losses, 20, 10)
+	sizer := &KellySizer{history: history, kellyFraction: defaultKellyFraction}
+
+	got, err := sizer.Size(100, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 100 * maxKellyFraction; got != want {
+		t.Errorf("got %v, want %v (capped at maxKellyFraction)", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+package risk
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	errMissingStop         = errors.New("risk: decision has no stop-loss for a strategy that requires one")
+	errRiskRewardTooLow    = errors.New("risk: reward is not worth at least the configured minimum multiple of risk")
+	errStopDistanceTooWide = errors.New("risk: stop distance is unreasonably wide relative to recent volatility")
+)
+
+// StopConfig is the configurable minimums ValidateStops enforces, see
+// settings.RequireStopLoss, settings.MinRiskRewardRatio and
+// settings.MaxStopDistanceATRMultiple.
+type StopConfig struct {
+	RequireStop                bool
+	MinRiskRewardRatio         float64
+	MaxStopDistanceATRMultiple float64
+}
+
+// ValidateStops checks that a trade plan's entry/stop/target make sense
+// before an order is placed: that a strategy requiring a stop-loss
+// actually has one, that the reward being targeted is worth at least
+// MinRiskRewardRatio times the risk being taken, and that the stop isn't
+// sitting so far from entry (relative to atr) that a single adverse move
+// could blow past it before it fills. stop <= 0 means the strategy
+// didn't place a stop-loss order at all; atr <= 0 skips the distance
+// check, since not every caller has a volatility estimate available.
+func ValidateStops(cfg StopConfig, entry, stop, target, atr float64) error {
+	if stop <= 0 {
+		if cfg.RequireStop {
+			return errMissingStop
+		}
+		return nil
+	}
+
+	distance := math.Abs(entry - stop)
+	if distance == 0 {
+		return errMissingStop
+	}
+
+	if cfg.MinRiskRewardRatio > 0 && target > 0 {
+		reward := math.Abs(target - entry)
+		if reward/distance < cfg.MinRiskRewardRatio {
+			return errRiskRewardTooLow
+		}
+	}
+
+	if cfg.MaxStopDistanceATRMultiple > 0 && atr > 0 && distance > atr*cfg.MaxStopDistanceATRMultiple {
+		return errStopDistanceTooWide
+	}
+
+	return nil
+}
+
+// WidenStopDistance pushes stop further from entry, preserving its
+// side (a stop below entry stays below, one above stays above), until
+// it's at least minDistance away. Binance's matching engine rejects a
+// STOP_MARKET/TAKE_PROFIT_MARKET order whose trigger sits too close to
+// the current mark price as "would immediately trigger" (error -2021);
+// minDistance is usually a small multiple of the symbol's tick size,
+// see orderer.create. stop <= 0 (no stop placed) and minDistance <= 0
+// (check disabled) are no-ops.
+func WidenStopDistance(entry, stop, minDistance float64) float64 {
+	if stop <= 0 || minDistance <= 0 {
+		return stop
+	}
+
+	switch {
+	case stop < entry && entry-stop < minDistance:
+		return entry - minDistance
+	case stop >= entry && stop-entry < minDistance:
+		return entry + minDistance
+	default:
+		return stop
+	}
+}
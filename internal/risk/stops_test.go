@@ -0,0 +1,27 @@
+package risk
+
+import "testing"
+
+func TestWidenStopDistanceTooClose(t *testing.T) {
+	cases := []struct {
+		name        string
+		entry       float64
+		stop        float64
+		minDistance float64
+		want        float64
+	}{
+		{"long stop below entry widened downward", 100, 99.95, 1, 99},
+		{"short stop above entry widened upward", 100, 100.05, 1, 101},
+		{"already wide enough is unchanged", 100, 95, 1, 95},
+		{"check disabled passes through unchanged", 100, 99.95, 0, 99.95},
+		{"no stop placed passes through unchanged", 100, 0, 1, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := WidenStopDistance(c.entry, c.stop, c.minDistance); got != c.want {
+				t.Errorf("WidenStopDistance(%v, %v, %v) = %v, want %v", c.entry, c.stop, c.minDistance, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package risk
+
+import (
+	"sync"
+	"time"
+)
+
+// ThrottleConfig bounds how many new positions may be opened per hour
+// and per day, globally and per symbol, to prevent over-trading in
+// choppy conditions.
+type ThrottleConfig struct {
+	MaxPerSymbolHourly int
+	MaxPerSymbolDaily  int
+	MaxGlobalHourly    int
+	MaxGlobalDaily     int
+}
+
+// Throttle tracks recent position-opening timestamps and rejects a new
+// one once any configured window/scope limit is reached.
+type Throttle struct {
+	cfg    ThrottleConfig
+	mux    sync.Mutex
+	global []time.Time
+	symbol map[string][]time.Time
+}
+
+// NewThrottle returns a Throttle enforcing cfg.
+func NewThrottle(cfg ThrottleConfig) *Throttle {
+	return &Throttle{
+		cfg:    cfg,
+		symbol: make(map[string][]time.Time),
+	}
+}
+
+// Allow reports whether opening a new position on symbol right now would
+// stay within every configured limit. It does not record anything
+// itself -- a caller that gets true back still has to run its own
+// downstream checks (liquidity, self-trade, the exchange call) before
+// the position is actually opened, so call Commit once it is. Recording
+// on Allow would burn the slot on a signal that never actually traded.
+func (t *Throttle) Allow(symbol string) bool {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	now := time.Now()
+
+	t.global = prune(t.global, now)
+	t.symbol[symbol] = prune(t.symbol[symbol], now)
+
+	return !(exceeds(t.global, now, time.Hour, t.cfg.MaxGlobalHourly) ||
+		exceeds(t.global, now, 24*time.Hour, t.cfg.MaxGlobalDaily) ||
+		exceeds(t.symbol[symbol], now, time.Hour, t.cfg.MaxPerSymbolHourly) ||
+		exceeds(t.symbol[symbol], now, 24*time.Hour, t.cfg.MaxPerSymbolDaily))
+}
+
+// Commit records that a position on symbol was actually opened, so
+// later Allow/Counts calls see it within the configured windows. Call
+// this once the order has cleared every downstream check Allow doesn't
+// know about, not when Allow itself returns true.
+func (t *Throttle) Commit(symbol string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	now := time.Now()
+
+	t.global = append(t.global, now)
+	t.symbol[symbol] = append(t.symbol[symbol], now)
+}
+
+// Counts returns the number of positions opened in the last hour and day
+// for symbol, for exposing as risk metrics.
+func (t *Throttle) Counts(symbol string) (hourly, daily int) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	now := time.Now()
+	timestamps := t.symbol[symbol]
+
+	for _, ts := range timestamps {
+		if now.Sub(ts) <= time.Hour {
+			hourly++
+		}
+		if now.Sub(ts) <= 24*time.Hour {
+			daily++
+		}
+	}
+
+	return hourly, daily
+}
+
+func exceeds(timestamps []time.Time, now time.Time, window time.Duration, limit int) bool {
+	if limit <= 0 {
+		return false
+	}
+
+	count := 0
+	for _, ts := range timestamps {
+		if now.Sub(ts) <= window {
+			count++
+		}
+	}
+
+	return count >= limit
+}
+
+// prune drops timestamps older than the widest window (24h) we care about.
+func prune(timestamps []time.Time, now time.Time) []time.Time {
+	kept := timestamps[:0]
+	for _, ts := range timestamps {
+		if now.Sub(ts) <= 24*time.Hour {
+			kept = append(kept, ts)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,55 @@
+package risk
+
+import "testing"
+
+func TestThrottleAllow(t *testing.T) {
+	throttle := NewThrottle(ThrottleConfig{MaxPerSymbolHourly: 1, MaxGlobalHourly: 2})
+
+	if !throttle.Allow("BTCUSDT") {
+		t.Fatal("first attempt should be allowed")
+	}
+	throttle.Commit("BTCUSDT")
+
+	if throttle.Allow("BTCUSDT") {
+		t.Error("second attempt on the same symbol within the hourly limit should be rejected")
+	}
+
+	if !throttle.Allow("ETHUSDT") {
+		t.Fatal("a different symbol should still be allowed")
+	}
+	throttle.Commit("ETHUSDT")
+
+	if throttle.Allow("SOLUSDT") {
+		t.Error("third attempt should be rejected by the global hourly limit")
+	}
+}
+
+func TestThrottleAllowWithoutCommitDoesNotRecord(t *testing.T) {
+	throttle := NewThrottle(ThrottleConfig{MaxPerSymbolHourly: 1, MaxGlobalHourly: 1})
+
+	if !throttle.Allow("BTCUSDT") {
+		t.Fatal("first preview should be allowed")
+	}
+
+	if !throttle.Allow("BTCUSDT") {
+		t.Error("previewing without committing should not burn the slot: second preview should still be allowed")
+	}
+
+	if hourly, _ := throttle.Counts("BTCUSDT"); hourly != 0 {
+		t.Errorf("previewing without committing should not be visible in Counts: got %v, want 0", hourly)
+	}
+}
+
+func TestThrottleCounts(t *testing.T) {
+	throttle := NewThrottle(ThrottleConfig{})
+
+	throttle.Allow("BTCUSDT")
+	throttle.Commit("BTCUSDT")
+	throttle.Allow("BTCUSDT")
+	throttle.Commit("BTCUSDT")
+
+	hourly, daily := throttle.Counts("BTCUSDT")
+	if hourly != 2 || daily != 2 {
+		t.Errorf("got hourly=%v daily=%v, want hourly=2 daily=2", hourly, daily)
+	}
+}
@@ -0,0 +1,76 @@
+package risk
+
+import "sort"
+
+// VolatilityRegime classifies how wide the current ATR sits relative to
+// its own recent history, so callers can scale multipliers and position
+// sizes instead of comparing ATR against fixed thresholds.
+type VolatilityRegime byte
+
+const (
+	VolatilityRegimeLow VolatilityRegime = iota
+	VolatilityRegimeNormal
+	VolatilityRegimeHigh
+	VolatilityRegimeExtreme
+)
+
+func (r VolatilityRegime) String() string {
+	switch r {
+	case VolatilityRegimeLow:
+		return "low"
+	case VolatilityRegimeHigh:
+		return "high"
+	case VolatilityRegimeExtreme:
+		return "extreme"
+	default:
+		return "normal"
+	}
+}
+
+// ClassifyVolatility ranks the current ATR against the lookback window
+// (percentile of current within history, history inclusive) and buckets
+// it into a regime:
+//
+//	p < 25  -> low
+//	p < 75  -> normal
+//	p < 95  -> high
+//	p >= 95 -> extreme
+func ClassifyVolatility(current float64, lookback []float64) VolatilityRegime {
+	if len(lookback) == 0 {
+		return VolatilityRegimeNormal
+	}
+
+	sorted := make([]float64, len(lookback))
+	copy(sorted, lookback)
+	sort.Float64s(sorted)
+
+	below := sort.SearchFloat64s(sorted, current)
+	percentile := float64(below) / float64(len(sorted)) * 100
+
+	switch {
+	case percentile < 25:
+		return VolatilityRegimeLow
+	case percentile < 75:
+		return VolatilityRegimeNormal
+	case percentile < 95:
+		return VolatilityRegimeHigh
+	default:
+		return VolatilityRegimeExtreme
+	}
+}
+
+// Multiplier returns the stop/target distance multiplier associated with
+// a regime, used by risk and decision logic to widen or tighten around
+// volatility spikes.
+func (r VolatilityRegime) Multiplier() float64 {
+	switch r {
+	case VolatilityRegimeLow:
+		return 0.8
+	case VolatilityRegimeHigh:
+		return 1.3
+	case VolatilityRegimeExtreme:
+		return 1.8
+	default:
+		return 1.0
+	}
+}
@@ -0,0 +1,91 @@
+package safety
+
+import (
+	"fmt"
+
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+// ConsecutiveLossRule counts consecutive losing trades from the tail of
+// TradingHistory and curtails trading before a full pause: size is
+// reduced after reduceAfter losses, and trading pauses entirely after
+// pauseAfter losses.
+type ConsecutiveLossRule struct {
+	reduceAfter int
+	pauseAfter  int
+	priority    int
+}
+
+// NewConsecutiveLossRule returns a rule that reduces size after
+// reduceAfter consecutive losses and pauses trading after pauseAfter.
+func NewConsecutiveLossRule(reduceAfter, pauseAfter int) *ConsecutiveLossRule {
+	return &ConsecutiveLossRule{reduceAfter: reduceAfter, pauseAfter: pauseAfter}
+}
+
+// WithPriority sets the priority Guard.Evaluate uses to break ties
+// against other same-Severity violations, and returns r for chaining.
+func (r *ConsecutiveLossRule) WithPriority(priority int) *ConsecutiveLossRule {
+	r.priority = priority
+	return r
+}
+
+func (r *ConsecutiveLossRule) Name() string {
+	return "consecutive_loss_breaker"
+}
+
+func (r *ConsecutiveLossRule) Priority() int {
+	return r.priority
+}
+
+func (r *ConsecutiveLossRule) Evaluate(ctx *Context) *Violation {
+	if ctx.History == nil {
+		return nil
+	}
+
+	streak := consecutiveLosses(ctx.History.Results())
+	if streak == 0 {
+		return nil
+	}
+
+	switch {
+	case r.pauseAfter > 0 && streak >= r.pauseAfter:
+		return &Violation{
+			Rule:     r.Name(),
+			Message:  fmt.Sprintf("%d consecutive losses, pausing trading", streak),
+			Severity: SeverityPause,
+		}
+
+	case r.reduceAfter > 0 && streak >= r.reduceAfter:
+		// linearly reduce size for every loss past reduceAfter, down to
+		// a floor of 25% of the normal size.
+		over := streak - r.reduceAfter + 1
+		multiplier := 1.0 - 0.25*float64(over)
+		if multiplier < 0.25 {
+			multiplier = 0.25
+		}
+
+		return &Violation{
+			Rule:           r.Name(),
+			Message:        fmt.Sprintf("%d consecutive losses, reducing size", streak),
+			Severity:       SeverityReduce,
+			SizeMultiplier: multiplier,
+		}
+	}
+
+	return nil
+}
+
+// consecutiveLosses counts losing trades from the most recent result
+// backwards until the first win.
+func consecutiveLosses(results []*models.TradeResult) int {
+	streak := 0
+
+	for i := len(results) - 1; i >= 0; i-- {
+		if results[i].Win {
+			break
+		}
+		streak++
+	}
+
+	return streak
+}
@@ -0,0 +1,315 @@
+package safety
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/metrics"
+	"github.com/anvh2/futures-trading/internal/services/telegram"
+	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Guard evaluates a set of Rules against a Context and returns the most
+// severe Violation for the caller to enforce (pausing, reducing, or
+// otherwise curtailing trading as the severity calls for).
+//
+// When DryRun is enabled, a tripped Violation is only logged and
+// notified rather than returned, so a misconfigured threshold can be
+// tuned against live data without actually flattening the book.
+type Guard struct {
+	logger   *logger.Logger
+	notify   *telegram.TelegramBot
+	settings *settings.Settings
+	rules    []Rule
+	dryRun   bool
+
+	// dedupWindow bounds how long a dry-run Violation from the same Rule
+	// and Severity stays "active": repeats inside the window only bump
+	// Violation.Occurrences instead of re-reporting, and a Rule that
+	// stops tripping is only reported resolved once it has been quiet
+	// for the full window, so a flapping condition doesn't spam
+	// new/resolved pairs. Zero disables dedup, reporting every trip.
+	dedupWindow time.Duration
+	activeMu    sync.Mutex
+	active      map[string]map[string]*Incident
+}
+
+// IncidentState is where an Incident sits in its opened -> acknowledged
+// -> (resolved, implicit by removal) lifecycle.
+type IncidentState string
+
+const (
+	IncidentOpened       IncidentState = "opened"
+	IncidentAcknowledged IncidentState = "acknowledged"
+)
+
+// Incident is a Rule+Severity that is still tripping (or was within the
+// last dedupWindow), tracked so ActiveIncidents/Acknowledge have
+// something stateful to query and act on instead of a flat,
+// append-only log of every individual trip.
+type Incident struct {
+	Symbol      string
+	Rule        string
+	Violation   *Violation
+	State       IncidentState
+	Occurrences int
+	FirstSeen   time.Time
+	LastSeen    time.Time
+}
+
+// NewGuard builds a Guard evaluating rules in order, with no severity
+// priority among them beyond the highest Severity returned winning.
+// Rules named in settings.DisabledSafetyRules are skipped, so they can
+// be toggled off at runtime without a restart.
+func NewGuard(logger *logger.Logger, notify *telegram.TelegramBot, settings *settings.Settings, dryRun bool, rules ...Rule) *Guard {
+	return &Guard{
+		logger:   logger,
+		notify:   notify,
+		settings: settings,
+		rules:    rules,
+		dryRun:   dryRun,
+		active:   make(map[string]map[string]*Incident),
+	}
+}
+
+// WithDedupWindow sets the window dry-run reporting uses to dedup
+// repeated violations and delay resolved events, see dedupWindow, and
+// returns g for chaining.
+func (g *Guard) WithDedupWindow(window time.Duration) *Guard {
+	g.dedupWindow = window
+	return g
+}
+
+// trip pairs a Rule's Violation with the Rule that raised it, so the
+// Priority used to break ties and the Name used to dedup travel with
+// the result instead of requiring a second pass back over g.rules.
+type trip struct {
+	rule      Rule
+	violation *Violation
+}
+
+// Evaluate runs every enabled rule against sctx concurrently and
+// returns the most severe Violation, breaking ties between equally
+// severe violations by the tripped Rules' Priority (lower wins), or
+// nil if none tripped. sctx is read-only for the duration of Evaluate,
+// so every rule can safely evaluate it in its own goroutine. In
+// dry-run mode every trip is deduped and reported (see dedupWindow)
+// but nil is always returned, so the caller never sees a reason to
+// act on it.
+func (g *Guard) Evaluate(ctx context.Context, sctx *Context) *Violation {
+	trips := make(chan trip, len(g.rules))
+
+	var wg sync.WaitGroup
+
+	for _, rule := range g.rules {
+		if g.settings.IsSafetyRuleDisabled(rule.Name()) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(rule Rule) {
+			defer wg.Done()
+
+			violation := rule.Evaluate(sctx)
+			if violation == nil {
+				return
+			}
+
+			trips <- trip{rule: rule, violation: violation}
+		}(rule)
+	}
+
+	wg.Wait()
+	close(trips)
+
+	all := make([]trip, 0, len(g.rules))
+	for t := range trips {
+		all = append(all, t)
+		metrics.GuardViolations.WithLabelValues(t.rule.Name(), actionFor(t.violation.Severity)).Inc()
+	}
+
+	opened, resolved := g.trackIncidents(sctx.Symbol, all)
+
+	if g.dryRun {
+		for _, incident := range opened {
+			g.reportDryRun(ctx, incident.Symbol, incident.Violation)
+		}
+
+		for _, incident := range resolved {
+			g.reportResolved(ctx, incident.Symbol, incident.Violation)
+		}
+
+		return nil
+	}
+
+	var worst *trip
+
+	for i, t := range all {
+		switch {
+		case worst == nil:
+		case t.violation.Severity < worst.violation.Severity:
+			continue
+		case t.violation.Severity == worst.violation.Severity && t.rule.Priority() >= worst.rule.Priority():
+			continue
+		}
+
+		worst = &all[i]
+	}
+
+	if worst == nil {
+		return nil
+	}
+
+	return worst.violation
+}
+
+// trackIncidents dedups trips against the Incidents still active for
+// symbol: a trip from a Rule+Severity already active within
+// dedupWindow only bumps Occurrences, anything else opens a new
+// Incident. Active Incidents missing from trips for a full dedupWindow
+// are resolved (dropped from active), so a single flickering miss
+// doesn't flap open/resolved pairs. It returns the Incidents newly
+// opened and newly resolved by this call, for the caller to report.
+func (g *Guard) trackIncidents(symbol string, trips []trip) (opened, resolved []*Incident) {
+	now := time.Now()
+
+	g.activeMu.Lock()
+	defer g.activeMu.Unlock()
+
+	active := g.active[symbol]
+	if active == nil {
+		active = make(map[string]*Incident)
+		g.active[symbol] = active
+	}
+
+	seen := make(map[string]bool, len(trips))
+
+	for _, t := range trips {
+		name := t.rule.Name()
+		seen[name] = true
+
+		if existing, ok := active[name]; ok && existing.Violation.Severity == t.violation.Severity && now.Sub(existing.LastSeen) <= g.dedupWindow {
+			existing.Occurrences++
+			existing.LastSeen = now
+			existing.Violation = t.violation
+			existing.Violation.Occurrences = existing.Occurrences
+			continue
+		}
+
+		t.violation.Occurrences = 1
+		incident := &Incident{
+			Symbol:      symbol,
+			Rule:        name,
+			Violation:   t.violation,
+			State:       IncidentOpened,
+			Occurrences: 1,
+			FirstSeen:   now,
+			LastSeen:    now,
+		}
+		active[name] = incident
+		opened = append(opened, incident)
+	}
+
+	for name, existing := range active {
+		if seen[name] || now.Sub(existing.LastSeen) <= g.dedupWindow {
+			continue
+		}
+
+		resolved = append(resolved, existing)
+		delete(active, name)
+	}
+
+	return opened, resolved
+}
+
+// ActiveIncidents returns a snapshot of every Incident still active
+// across all symbols, most recently seen first, for Telegram/admin API
+// callers to list without reaching into Guard internals.
+func (g *Guard) ActiveIncidents() []*Incident {
+	g.activeMu.Lock()
+	defer g.activeMu.Unlock()
+
+	incidents := make([]*Incident, 0)
+
+	for _, rules := range g.active {
+		for _, incident := range rules {
+			copied := *incident
+			incidents = append(incidents, &copied)
+		}
+	}
+
+	sort.Slice(incidents, func(i, j int) bool {
+		return incidents[i].LastSeen.After(incidents[j].LastSeen)
+	})
+
+	return incidents
+}
+
+// Acknowledge marks the active Incident for symbol+ruleName as seen by
+// an operator, so it stops demanding attention without having to
+// actually clear on its own. It returns an error if no such Incident
+// is currently active.
+func (g *Guard) Acknowledge(symbol, ruleName string) error {
+	g.activeMu.Lock()
+	defer g.activeMu.Unlock()
+
+	incident, ok := g.active[symbol][ruleName]
+	if !ok {
+		return fmt.Errorf("safety: no active incident for %s/%s", symbol, ruleName)
+	}
+
+	incident.State = IncidentAcknowledged
+	return nil
+}
+
+// reportDryRun logs and notifies the action that would have fired, had
+// the Guard not been in dry-run mode.
+func (g *Guard) reportDryRun(ctx context.Context, symbol string, violation *Violation) {
+	event := logger.Event{Type: "safety.dry_run", Symbol: symbol, Severity: logger.SeverityWarning}
+	g.logger.Warn("[Guard] dry-run violation would have fired",
+		append(event.Fields(), zap.String("rule", violation.Rule), zap.String("action", actionFor(violation.Severity)))...)
+
+	if g.notify == nil {
+		return
+	}
+
+	msg := fmt.Sprintf("[dry-run] %s would have %s %s: %s", violation.Rule, actionFor(violation.Severity), symbol, violation.Message)
+	if err := g.notify.PushNotify(ctx, viper.GetInt64("notify.channels.futures_announcement"), msg); err != nil {
+		g.logger.Error("[Guard] failed to push dry-run notification", append(event.Fields(), zap.Error(err))...)
+	}
+}
+
+// reportResolved logs and notifies that a previously active dry-run
+// violation has stopped tripping for a full dedupWindow.
+func (g *Guard) reportResolved(ctx context.Context, symbol string, violation *Violation) {
+	event := logger.Event{Type: "safety.dry_run_resolved", Symbol: symbol, Severity: logger.SeverityInfo}
+	g.logger.Info("[Guard] dry-run violation resolved", append(event.Fields(), zap.String("rule", violation.Rule))...)
+
+	if g.notify == nil {
+		return
+	}
+
+	msg := fmt.Sprintf("[dry-run] %s on %s resolved", violation.Rule, symbol)
+	if err := g.notify.PushNotify(ctx, viper.GetInt64("notify.channels.futures_announcement"), msg); err != nil {
+		g.logger.Error("[Guard] failed to push dry-run resolution notification", append(event.Fields(), zap.Error(err))...)
+	}
+}
+
+// actionFor names the enforcement action a Severity maps to, for
+// human-readable dry-run reporting.
+func actionFor(severity Severity) string {
+	switch severity {
+	case SeverityPause:
+		return "paused"
+	case SeverityReduce:
+		return "reduced"
+	default:
+		return "warned on"
+	}
+}
@@ -0,0 +1,29 @@
+package safety
+
+import "os"
+
+// KillSwitch detects a file or environment variable trigger, for a
+// last-resort emergency stop that works even when the API and Telegram
+// command interface are unreachable.
+type KillSwitch struct {
+	filePath string
+	envVar   string
+}
+
+// NewKillSwitch returns a KillSwitch watching filePath and envVar.
+// Either left empty disables that trigger.
+func NewKillSwitch(filePath, envVar string) *KillSwitch {
+	return &KillSwitch{filePath: filePath, envVar: envVar}
+}
+
+// Tripped reports whether the kill-switch file exists or the
+// environment variable is set to a non-empty value.
+func (k *KillSwitch) Tripped() bool {
+	if k.filePath != "" {
+		if _, err := os.Stat(k.filePath); err == nil {
+			return true
+		}
+	}
+
+	return k.envVar != "" && os.Getenv(k.envVar) != ""
+}
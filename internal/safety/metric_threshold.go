@@ -0,0 +1,84 @@
+package safety
+
+import "fmt"
+
+// Operator is a comparison used by MetricThresholdRule.
+type Operator string
+
+const (
+	OperatorGT  Operator = ">"
+	OperatorGTE Operator = ">="
+	OperatorLT  Operator = "<"
+	OperatorLTE Operator = "<="
+	OperatorEQ  Operator = "=="
+)
+
+// MetricThresholdRule trips when a named Context.Metrics value compares
+// against threshold per operator, so a new safety check can be composed
+// from config (see RuleConfig/BuildRules) instead of a new Go type.
+type MetricThresholdRule struct {
+	name      string
+	metric    string
+	operator  Operator
+	threshold float64
+	severity  Severity
+	priority  int
+}
+
+// NewMetricThresholdRule returns a rule named name that trips Severity
+// when Context.Metrics[metric] compares true against threshold per
+// operator. A metric missing from Context.Metrics never trips.
+func NewMetricThresholdRule(name, metric string, operator Operator, threshold float64, severity Severity) *MetricThresholdRule {
+	return &MetricThresholdRule{
+		name:      name,
+		metric:    metric,
+		operator:  operator,
+		threshold: threshold,
+		severity:  severity,
+	}
+}
+
+// WithPriority sets the priority Guard.Evaluate uses to break ties
+// against other same-Severity violations, and returns r for chaining.
+func (r *MetricThresholdRule) WithPriority(priority int) *MetricThresholdRule {
+	r.priority = priority
+	return r
+}
+
+func (r *MetricThresholdRule) Name() string {
+	return r.name
+}
+
+func (r *MetricThresholdRule) Priority() int {
+	return r.priority
+}
+
+func (r *MetricThresholdRule) Evaluate(ctx *Context) *Violation {
+	value, ok := ctx.Metrics[r.metric]
+	if !ok || !r.trips(value) {
+		return nil
+	}
+
+	return &Violation{
+		Rule:     r.Name(),
+		Message:  fmt.Sprintf("%s %s %.4f (actual %.4f)", r.metric, r.operator, r.threshold, value),
+		Severity: r.severity,
+	}
+}
+
+func (r *MetricThresholdRule) trips(value float64) bool {
+	switch r.operator {
+	case OperatorGT:
+		return value > r.threshold
+	case OperatorGTE:
+		return value >= r.threshold
+	case OperatorLT:
+		return value < r.threshold
+	case OperatorLTE:
+		return value <= r.threshold
+	case OperatorEQ:
+		return value == r.threshold
+	default:
+		return false
+	}
+}
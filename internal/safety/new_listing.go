@@ -0,0 +1,44 @@
+package safety
+
+import "fmt"
+
+// NewListingRule pauses trading on symbols that were listed too
+// recently, since freshly listed contracts tend to go through violent,
+// thin-liquidity price discovery before settling into a tradable range.
+type NewListingRule struct {
+	minDaysListed int
+	priority      int
+}
+
+// NewNewListingRule returns a rule that pauses trading on symbols listed
+// fewer than minDaysListed days ago.
+func NewNewListingRule(minDaysListed int) *NewListingRule {
+	return &NewListingRule{minDaysListed: minDaysListed}
+}
+
+// WithPriority sets the priority Guard.Evaluate uses to break ties
+// against other same-Severity violations, and returns r for chaining.
+func (r *NewListingRule) WithPriority(priority int) *NewListingRule {
+	r.priority = priority
+	return r
+}
+
+func (r *NewListingRule) Name() string {
+	return "new_listing_guard"
+}
+
+func (r *NewListingRule) Priority() int {
+	return r.priority
+}
+
+func (r *NewListingRule) Evaluate(ctx *Context) *Violation {
+	if r.minDaysListed <= 0 || ctx.DaysListed <= 0 || ctx.DaysListed >= r.minDaysListed {
+		return nil
+	}
+
+	return &Violation{
+		Rule:     r.Name(),
+		Message:  fmt.Sprintf("%s listed %d day(s) ago, below the %d day minimum", ctx.Symbol, ctx.DaysListed, r.minDaysListed),
+		Severity: SeverityPause,
+	}
+}
@@ -0,0 +1,44 @@
+package safety
+
+// NewsKillSwitchRule pauses trading on a symbol a news feed keyword
+// monitor has flagged (e.g. a headline mentioning "hack", "delisting",
+// "SEC" alongside the symbol), so new entries stop on credible bad news
+// without waiting for price to react first. See
+// crawler.Crawler.startNewsCheck, which populates
+// exchange.Exchange.NewsFlag / Context.NewsHeadline.
+type NewsKillSwitchRule struct {
+	priority int
+}
+
+// NewNewsKillSwitchRule returns a rule pausing trading on symbols
+// currently flagged by the news feed monitor.
+func NewNewsKillSwitchRule() *NewsKillSwitchRule {
+	return &NewsKillSwitchRule{}
+}
+
+// WithPriority sets the priority Guard.Evaluate uses to break ties
+// against other same-Severity violations, and returns r for chaining.
+func (r *NewsKillSwitchRule) WithPriority(priority int) *NewsKillSwitchRule {
+	r.priority = priority
+	return r
+}
+
+func (r *NewsKillSwitchRule) Name() string {
+	return "news_kill_switch"
+}
+
+func (r *NewsKillSwitchRule) Priority() int {
+	return r.priority
+}
+
+func (r *NewsKillSwitchRule) Evaluate(ctx *Context) *Violation {
+	if ctx.NewsHeadline == "" {
+		return nil
+	}
+
+	return &Violation{
+		Rule:     r.Name(),
+		Message:  ctx.Symbol + " flagged by news monitor: " + ctx.NewsHeadline,
+		Severity: SeverityPause,
+	}
+}
@@ -0,0 +1,187 @@
+package safety
+
+import (
+	"fmt"
+
+	"github.com/anvh2/futures-trading/internal/settings"
+)
+
+// Factory builds a Rule from its config Params, see Register/Build.
+type Factory func(params map[string]interface{}) (Rule, error)
+
+var factories = map[string]Factory{
+	"consecutive_loss": newConsecutiveLossFactory,
+	"new_listing":      newNewListingFactory,
+	"metric_threshold": newMetricThresholdFactory,
+	"trading_status":   newTradingStatusFactory,
+	"news_kill_switch": newNewsKillSwitchFactory,
+}
+
+// Register adds (or overrides) the Factory used for ruleType, so a
+// caller can register additional rule types beyond the built-in ones.
+func Register(ruleType string, factory Factory) {
+	factories[ruleType] = factory
+}
+
+// Build constructs the Rule configured by config using the Factory
+// registered for config.Type.
+func Build(config settings.RuleConfig) (Rule, error) {
+	factory, ok := factories[config.Type]
+	if !ok {
+		return nil, fmt.Errorf("safety: unknown rule type %q", config.Type)
+	}
+
+	return factory(config.Params)
+}
+
+// BuildRules constructs every Rule in configs, in order, stopping at
+// the first error.
+func BuildRules(configs []settings.RuleConfig) ([]Rule, error) {
+	rules := make([]Rule, 0, len(configs))
+
+	for _, config := range configs {
+		rule, err := Build(config)
+		if err != nil {
+			return nil, fmt.Errorf("safety: failed to build rule %q: %w", config.Type, err)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func newConsecutiveLossFactory(params map[string]interface{}) (Rule, error) {
+	reduceAfter, err := paramInt(params, "reduce_after", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pauseAfter, err := paramInt(params, "pause_after", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := paramInt(params, "priority", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewConsecutiveLossRule(reduceAfter, pauseAfter).WithPriority(priority), nil
+}
+
+func newNewListingFactory(params map[string]interface{}) (Rule, error) {
+	minDaysListed, err := paramInt(params, "min_days_listed", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := paramInt(params, "priority", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNewListingRule(minDaysListed).WithPriority(priority), nil
+}
+
+func newTradingStatusFactory(params map[string]interface{}) (Rule, error) {
+	priority, err := paramInt(params, "priority", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTradingStatusRule().WithPriority(priority), nil
+}
+
+func newNewsKillSwitchFactory(params map[string]interface{}) (Rule, error) {
+	priority, err := paramInt(params, "priority", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewNewsKillSwitchRule().WithPriority(priority), nil
+}
+
+func newMetricThresholdFactory(params map[string]interface{}) (Rule, error) {
+	name, err := paramString(params, "name", "")
+	if err != nil {
+		return nil, err
+	}
+
+	metric, err := paramString(params, "metric", "")
+	if err != nil {
+		return nil, err
+	}
+
+	operator, err := paramString(params, "operator", "")
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" || metric == "" || operator == "" {
+		return nil, fmt.Errorf("safety: metric_threshold requires name, metric, and operator params")
+	}
+
+	threshold, err := paramFloat(params, "threshold", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	severity, err := paramInt(params, "severity", int(SeverityWarn))
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := paramInt(params, "priority", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMetricThresholdRule(name, metric, Operator(operator), threshold, Severity(severity)).WithPriority(priority), nil
+}
+
+func paramInt(params map[string]interface{}, key string, def int) (int, error) {
+	value, ok := params[key]
+	if !ok {
+		return def, nil
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return int(v), nil
+	case int:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("safety: param %q must be a number", key)
+	}
+}
+
+func paramFloat(params map[string]interface{}, key string, def float64) (float64, error) {
+	value, ok := params[key]
+	if !ok {
+		return def, nil
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("safety: param %q must be a number", key)
+	}
+}
+
+func paramString(params map[string]interface{}, key, def string) (string, error) {
+	value, ok := params[key]
+	if !ok {
+		return def, nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("safety: param %q must be a string", key)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,60 @@
+package safety
+
+import "github.com/anvh2/futures-trading/internal/models"
+
+// Severity ranks how strongly a Rule wants trading curtailed.
+type Severity byte
+
+const (
+	SeverityWarn Severity = iota
+	SeverityReduce
+	SeverityPause
+)
+
+// Violation is returned by a Rule when it wants to curtail trading.
+type Violation struct {
+	Rule     string   `json:"rule,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Severity Severity `json:"severity,omitempty"`
+	// SizeMultiplier scales down position sizing when Severity is
+	// SeverityReduce; ignored otherwise.
+	SizeMultiplier float64 `json:"size_multiplier,omitempty"`
+	// Occurrences is how many consecutive times Guard has seen this
+	// same Rule+Severity trip within its dedup window. Rules leave it
+	// zero; Guard fills it in for dry-run reporting.
+	Occurrences int `json:"occurrences,omitempty"`
+}
+
+// Context is the information a Rule needs to decide whether to trip.
+type Context struct {
+	Symbol  string
+	History *models.TradingHistory
+	// DaysListed is how long the symbol has traded on the exchange, see
+	// exchange.Symbol.DaysListed. Zero means unknown/unavailable.
+	DaysListed int
+	// Metrics holds arbitrary named state metrics (e.g. drawdown_percent,
+	// account_balance) for MetricThresholdRule to compare against a
+	// configured threshold without a Go code change per metric.
+	Metrics map[string]float64
+	// SymbolStatus is the exchange-reported trading status for Symbol,
+	// see exchange.Symbol.Status. Empty means unknown/unavailable.
+	SymbolStatus string
+	// ExchangeMaintenance is whether Binance last reported being in an
+	// exchange-wide maintenance window, see exchange.Exchange.Maintenance.
+	ExchangeMaintenance bool
+	// NewsHeadline is the matched headline if a news feed keyword hit
+	// has flagged Symbol, see exchange.Exchange.NewsFlag and
+	// NewsKillSwitchRule. Empty means Symbol isn't currently flagged.
+	NewsHeadline string
+}
+
+// Rule evaluates one safety condition against the current context,
+// returning a Violation if trading should be curtailed.
+type Rule interface {
+	Name() string
+	Evaluate(ctx *Context) *Violation
+	// Priority breaks ties between two Rules that trip with the same
+	// Severity in the same Guard.Evaluate call: the lower value wins.
+	// Rules built without an explicit priority default to 0.
+	Priority() int
+}
@@ -0,0 +1,127 @@
+package safety
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/settings"
+)
+
+// scenarioStep is one tick of a replayed market scenario: the Context
+// Guard.Evaluate would see at that point in time, and the Violation
+// (nil meaning none) expected to fire as a result.
+type scenarioStep struct {
+	ctx          *Context
+	wantRule     string
+	wantSeverity Severity
+}
+
+// runScenario replays steps through guard in order and asserts each
+// one trips (or doesn't trip) the expected Rule/Severity, failing with
+// the step index ("latency") a mismatch was observed at.
+func runScenario(t *testing.T, guard *Guard, steps []scenarioStep) {
+	t.Helper()
+
+	for i, step := range steps {
+		violation := guard.Evaluate(context.Background(), step.ctx)
+
+		if step.wantRule == "" {
+			if violation != nil {
+				t.Fatalf("step %d: expected no breaker to fire, got %q at severity %d", i, violation.Rule, violation.Severity)
+			}
+			continue
+		}
+
+		if violation == nil {
+			t.Fatalf("step %d: expected %q to fire, got no violation", i, step.wantRule)
+		}
+
+		if violation.Rule != step.wantRule {
+			t.Fatalf("step %d: expected %q to fire, got %q", i, step.wantRule, violation.Rule)
+		}
+
+		if violation.Severity != step.wantSeverity {
+			t.Fatalf("step %d: expected %q severity %d, got %d", i, step.wantRule, step.wantSeverity, violation.Severity)
+		}
+	}
+}
+
+// TestFlashCrashTripsDrawdownBreakerBeforePause replays a flash-crash
+// scenario as a fast-widening drawdown_percent metric and asserts the
+// drawdown breaker stays quiet under threshold, then pauses trading
+// the instant it's crossed, with no intermediate warn/reduce step —
+// the hallmark of a flash crash versus a slow bear market.
+func TestFlashCrashTripsDrawdownBreakerBeforePause(t *testing.T) {
+	guard := NewGuard(logger.NewDev(), nil, settings.NewDefaultSettings(), false,
+		NewMetricThresholdRule("drawdown_breaker", "drawdown_percent", OperatorGTE, 20, SeverityPause),
+	)
+
+	steps := []scenarioStep{
+		{ctx: &Context{Symbol: "BTCUSDT", Metrics: map[string]float64{"drawdown_percent": 2}}},
+		{ctx: &Context{Symbol: "BTCUSDT", Metrics: map[string]float64{"drawdown_percent": 6}}},
+		{ctx: &Context{Symbol: "BTCUSDT", Metrics: map[string]float64{"drawdown_percent": 11}}},
+		{
+			ctx:          &Context{Symbol: "BTCUSDT", Metrics: map[string]float64{"drawdown_percent": 24}},
+			wantRule:     "drawdown_breaker",
+			wantSeverity: SeverityPause,
+		},
+		{
+			ctx:          &Context{Symbol: "BTCUSDT", Metrics: map[string]float64{"drawdown_percent": 31}},
+			wantRule:     "drawdown_breaker",
+			wantSeverity: SeverityPause,
+		},
+	}
+
+	runScenario(t, guard, steps)
+}
+
+// TestBearMarketReducesBeforePausing replays a bear-market scenario as
+// a slow-growing consecutive-loss streak and asserts trading is
+// reduced well before it's paused, in that order, matching
+// ConsecutiveLossRule's reduceAfter/pauseAfter thresholds.
+func TestBearMarketReducesBeforePausing(t *testing.T) {
+	guard := NewGuard(logger.NewDev(), nil, settings.NewDefaultSettings(), false,
+		NewConsecutiveLossRule(3, 6),
+	)
+
+	history := models.NewTradingHistory(100)
+	ctx := &Context{Symbol: "ETHUSDT", History: history}
+
+	addLoss := func() {
+		history.Add(&models.TradeResult{Symbol: "ETHUSDT", Win: false, PNL: -1})
+	}
+
+	// losses 1-2: under reduceAfter, nothing fires yet.
+	addLoss()
+	runScenario(t, guard, []scenarioStep{{ctx: ctx}})
+	addLoss()
+	runScenario(t, guard, []scenarioStep{{ctx: ctx}})
+
+	// losses 3-5: reduceAfter is met, size reduction kicks in.
+	for i := 0; i < 3; i++ {
+		addLoss()
+		runScenario(t, guard, []scenarioStep{{
+			ctx:          ctx,
+			wantRule:     "consecutive_loss_breaker",
+			wantSeverity: SeverityReduce,
+		}})
+	}
+
+	// loss 6: pauseAfter is met, trading pauses outright.
+	addLoss()
+	runScenario(t, guard, []scenarioStep{{
+		ctx:          ctx,
+		wantRule:     "consecutive_loss_breaker",
+		wantSeverity: SeverityPause,
+	}})
+
+	// a further loss keeps it paused, not re-escalated to something else.
+	addLoss()
+	runScenario(t, guard, []scenarioStep{{
+		ctx:          ctx,
+		wantRule:     "consecutive_loss_breaker",
+		wantSeverity: SeverityPause,
+	}})
+}
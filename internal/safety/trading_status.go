@@ -0,0 +1,50 @@
+package safety
+
+// TradingStatusRule pauses trading on a symbol the exchange has halted
+// (e.g. BREAK, SETTLING) and pauses trading globally while Binance
+// reports an exchange-wide maintenance window, since an order placed
+// into either is expected to fail or fill at a stale price.
+type TradingStatusRule struct {
+	priority int
+}
+
+// NewTradingStatusRule returns a rule pausing trading on halted
+// symbols and during exchange maintenance windows.
+func NewTradingStatusRule() *TradingStatusRule {
+	return &TradingStatusRule{}
+}
+
+// WithPriority sets the priority Guard.Evaluate uses to break ties
+// against other same-Severity violations, and returns r for chaining.
+func (r *TradingStatusRule) WithPriority(priority int) *TradingStatusRule {
+	r.priority = priority
+	return r
+}
+
+func (r *TradingStatusRule) Name() string {
+	return "trading_status_guard"
+}
+
+func (r *TradingStatusRule) Priority() int {
+	return r.priority
+}
+
+func (r *TradingStatusRule) Evaluate(ctx *Context) *Violation {
+	if ctx.ExchangeMaintenance {
+		return &Violation{
+			Rule:     r.Name(),
+			Message:  "exchange is in a maintenance window",
+			Severity: SeverityPause,
+		}
+	}
+
+	if ctx.SymbolStatus != "" && ctx.SymbolStatus != "TRADING" {
+		return &Violation{
+			Rule:     r.Name(),
+			Message:  ctx.Symbol + " is halted (status " + ctx.SymbolStatus + ")",
+			Severity: SeverityPause,
+		}
+	}
+
+	return nil
+}
@@ -3,8 +3,8 @@ package analyzer
 import (
 	"log"
 
+	"github.com/anvh2/futures-trading/internal/broadcast"
 	"github.com/anvh2/futures-trading/internal/cache"
-	"github.com/anvh2/futures-trading/internal/cache/basic"
 	"github.com/anvh2/futures-trading/internal/channel"
 	"github.com/anvh2/futures-trading/internal/libs/queue"
 	"github.com/anvh2/futures-trading/internal/logger"
@@ -22,8 +22,10 @@ type Analyzer struct {
 	exchangeCache cache.Exchange
 	queue         *queue.Queue
 	channel       *channel.Channel
+	broadcast     *broadcast.Hub
 	settings      *settings.Settings
 	notify        *telegram.TelegramBot
+	digest        *digest
 	quitChannel   chan struct{}
 }
 
@@ -34,7 +36,9 @@ func New(
 	exchangeCache cache.Exchange,
 	queue *queue.Queue,
 	channel *channel.Channel,
+	broadcast *broadcast.Hub,
 	settings *settings.Settings,
+	cache cache.Basic,
 ) *Analyzer {
 	worker, err := worker.New(logger, &worker.PoolConfig{NumProcess: 8})
 	if err != nil {
@@ -45,12 +49,14 @@ func New(
 		logger:        logger,
 		notify:        notify,
 		worker:        worker,
-		cache:         basic.NewCache(),
+		cache:         cache,
 		marketCache:   marketCache,
 		exchangeCache: exchangeCache,
 		channel:       channel,
+		broadcast:     broadcast,
 		queue:         queue,
 		settings:      settings,
+		digest:        &digest{},
 		quitChannel:   make(chan struct{}),
 	}
 
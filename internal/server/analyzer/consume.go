@@ -8,11 +8,12 @@ import (
 	"github.com/anvh2/futures-trading/internal/constants"
 	"github.com/anvh2/futures-trading/internal/helpers"
 	"github.com/anvh2/futures-trading/internal/models"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
 func (s *Analyzer) Start() error {
+	s.startDigest()
+
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
@@ -36,7 +37,7 @@ func (s *Analyzer) Start() error {
 						Candles: make(map[string]*models.CandlesData),
 					}
 
-					for _, interval := range viper.GetStringSlice("market.intervals") {
+					for _, interval := range symbolIntervals(symbol) {
 						candles, err := summary.Candles(interval)
 						if err != nil {
 							break
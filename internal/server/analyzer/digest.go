@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// digest buffers low-priority signal lines for batched delivery, so a
+// run of below-threshold signals pages once every DigestIntervalMinutes
+// instead of once per signal. Safe for concurrent use.
+type digest struct {
+	mux   sync.Mutex
+	lines []string
+}
+
+// add appends line to the pending digest buffer.
+func (d *digest) add(line string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.lines = append(d.lines, line)
+}
+
+// drain returns and clears the pending digest buffer.
+func (d *digest) drain() []string {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	lines := d.lines
+	d.lines = nil
+	return lines
+}
+
+// startDigest periodically flushes the pending digest buffer into a
+// single Telegram message, following the same ticker+quitChannel
+// lifecycle as the worker loop started from Start.
+func (s *Analyzer) startDigest() {
+	interval := time.Duration(s.settings.DigestIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.flushDigest(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// flushDigest sends the buffered low-priority signals as one message,
+// if any have accumulated since the last flush.
+func (s *Analyzer) flushDigest(ctx context.Context) {
+	lines := s.digest.drain()
+	if len(lines) == 0 {
+		return
+	}
+
+	msg := fmt.Sprintf("digest: %d low-priority signal(s)\n\n%s", len(lines), strings.Join(lines, "\n"))
+
+	event := logger.Event{Type: "signal.digest_flushed", Severity: logger.SeverityInfo}
+
+	if err := s.notify.PushNotify(ctx, viper.GetInt64("notify.channels.futures_announcement"), msg); err != nil {
+		s.logger.Error("[Digest] failed to push notification", append(event.Fields(), zap.Error(err))...)
+	}
+}
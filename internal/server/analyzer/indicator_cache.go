@@ -0,0 +1,33 @@
+package analyzer
+
+import "fmt"
+
+// cachedIndicatorKey builds the cache key for one memoized indicator
+// result, see cachedIndicator.
+func cachedIndicatorKey(symbol, interval, indicator, params string, lastCandleTime int64) string {
+	return fmt.Sprintf("indicator.result.%s.%s.%s.%s.%d", symbol, interval, indicator, params, lastCandleTime)
+}
+
+// cachedIndicator returns the result of compute, memoized under
+// (symbol, interval, indicator, params, lastCandleTime), so repeated
+// scoring of the same symbol within a still-open candle period doesn't
+// re-run a full-series talib/regime computation (ADX, Bollinger Band
+// width, Hurst, ATR, ...) on every tick; only a newly closed candle (a
+// changed lastCandleTime) recomputes it. params should be a stable
+// string encoding of whatever tunable inputs compute closes over (e.g.
+// "14" for a 14-period ATR), so two different parameterizations of the
+// same indicator never collide. Unlike indicatorState, this has no
+// incremental update path of its own — compute still runs the full
+// series, just at most once per candle close instead of once per tick.
+func cachedIndicator[T any](s *Analyzer, symbol, interval, indicator, params string, lastCandleTime int64, compute func() T) T {
+	key := cachedIndicatorKey(symbol, interval, indicator, params, lastCandleTime)
+
+	if cached, ok := s.cache.Get(key).(T); ok {
+		return cached
+	}
+
+	result := compute()
+	s.cache.Set(key, result)
+
+	return result
+}
@@ -0,0 +1,18 @@
+package analyzer
+
+import "github.com/spf13/viper"
+
+// symbolIntervals returns the candle intervals expected for symbol,
+// falling back to market.intervals when symbol has no entry under
+// market.symbol_intervals. Mirrors crawler.symbolIntervals so the
+// analyzer only expects data for the intervals actually streamed for
+// symbol, instead of flagging the rest as MissingIntervals.
+func symbolIntervals(symbol string) []string {
+	if overrides := viper.GetStringMapStringSlice("market.symbol_intervals"); len(overrides) > 0 {
+		if intervals, ok := overrides[symbol]; ok && len(intervals) > 0 {
+			return intervals
+		}
+	}
+
+	return viper.GetStringSlice("market.intervals")
+}
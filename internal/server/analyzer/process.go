@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/anvh2/futures-trading/internal/broadcast"
 	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/logger"
 	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/regime"
+	"github.com/anvh2/futures-trading/internal/risk"
 	"github.com/anvh2/futures-trading/internal/talib"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -23,6 +26,118 @@ func validateMessage(message *models.CandleSummary) error {
 	return nil
 }
 
+// classifyRegime tags the trading interval's market behavior from its
+// ADX, Bollinger Band width and Hurst estimate, so the decision engine
+// can eventually switch between breakout and mean-reversion scoring
+// instead of applying one scoring behavior to every regime.
+func classifyRegime(high, low, closing []float64) regime.Regime {
+	adx := talib.ADX(14, high, low, closing)
+	bandWidth := talib.BollingerBandWidth(20, 2, closing)
+	hurst := regime.EstimateHurst(closing)
+
+	return regime.Classify(regime.DefaultThresholds, adx[len(adx)-1], bandWidth[len(bandWidth)-1], hurst)
+}
+
+// tradingHistory returns the rolling TradeResult history tracked for
+// symbol under the current trading strategy, so confidence can
+// self-calibrate against recent performance. Returns nil until the
+// orderer has recorded at least one closed trade for this key.
+func (s *Analyzer) tradingHistory(symbol string) *models.TradingHistory {
+	key := fmt.Sprintf("history.%s.%d", symbol, s.settings.TradingStrategy)
+
+	history, _ := s.cache.Get(key).(*models.TradingHistory)
+	return history
+}
+
+// recordDecisionAudit logs oscillator and the trading history it was
+// scored against as a risk.DecisionInput, under the "decision.computed"
+// event type with a DecisionID set, so cmd/decision.go's replay tool
+// can later recompute risk.ComputeDecision against alternative
+// risk.DecisionParams without re-running the whole analyzer pipeline.
+func (s *Analyzer) recordDecisionAudit(symbol string, oscillator *models.Oscillator) {
+	decisionID := fmt.Sprintf("%s.%d", symbol, time.Now().UnixMilli())
+
+	input := &risk.DecisionInput{
+		Symbol:     symbol,
+		Oscillator: oscillator,
+		History:    s.tradingHistory(symbol),
+	}
+
+	event := logger.Event{Type: "decision.computed", Symbol: symbol, DecisionID: decisionID, Severity: logger.SeverityInfo}
+	s.logger.Info("[Process] decision computed", append(event.Fields(), zap.Any("input", input))...)
+}
+
+// SymbolStats returns symbol's aggregated performance stats under the
+// current trading strategy, for the dashboard API. Returns nil until at
+// least one closed trade has been recorded for this key.
+func (s *Analyzer) SymbolStats(symbol string) *models.SymbolStats {
+	history := s.tradingHistory(symbol)
+	if history == nil {
+		return nil
+	}
+
+	return history.Stats(symbol)
+}
+
+// TradingResults returns symbol's rolling window of closed trades under
+// the current trading strategy, oldest first, for report.Report's
+// benchmark comparison. Returns nil until at least one closed trade has
+// been recorded for this key.
+func (s *Analyzer) TradingResults(symbol string) []*models.TradeResult {
+	history := s.tradingHistory(symbol)
+	if history == nil {
+		return nil
+	}
+
+	return history.Results()
+}
+
+// indicatorState is the incremental RSI/KDJ state tracked for one
+// symbol+interval, so process only folds in the latest candle on each
+// tick instead of replaying the whole candle history through
+// talib.RSIPeriod/talib.KDJ every time. lastCandleTime guards against
+// advancing the state more than once for the same candle, since the
+// consume ticker fires far more often than any interval closes.
+type indicatorState struct {
+	rsi            *talib.RSIState
+	kdj            *talib.KDJState
+	lastCandleTime int64
+	lastRSI        float64
+	lastK, lastD   float64
+}
+
+// update folds the candle opened at openTime into the state, unless
+// it's the same candle already folded in on a prior tick, in which
+// case the previously computed RSI/K/D are returned unchanged.
+func (st *indicatorState) update(openTime int64, high, low, close float64) (rsi, k, d float64) {
+	if openTime == st.lastCandleTime {
+		return st.lastRSI, st.lastK, st.lastD
+	}
+
+	st.lastCandleTime = openTime
+	st.lastRSI = st.rsi.Update(close)
+	st.lastK, st.lastD, _ = st.kdj.Update(high, low, close)
+
+	return st.lastRSI, st.lastK, st.lastD
+}
+
+// indicatorState returns the incremental RSI/KDJ state for symbol on
+// interval, creating it on first use.
+func (s *Analyzer) indicatorState(symbol, interval string) *indicatorState {
+	key := fmt.Sprintf("indicator.state.%s.%s", symbol, interval)
+
+	state, _ := s.cache.Get(key).(*indicatorState)
+	if state == nil {
+		state = &indicatorState{
+			rsi: talib.NewRSIState(14),
+			kdj: talib.NewKDJState(9, 3, 3),
+		}
+		s.cache.Set(key, state)
+	}
+
+	return state
+}
+
 func (s *Analyzer) process(ctx context.Context, data interface{}) error {
 	message := &models.CandleSummary{
 		Candles: make(map[string]*models.CandlesData),
@@ -38,78 +153,152 @@ func (s *Analyzer) process(ctx context.Context, data interface{}) error {
 		return err
 	}
 
+	if s.settings.IsBlacklisted(message.Symbol) {
+		return errors.New("analyze: symbol is blacklisted")
+	}
+
 	oscillator := &models.Oscillator{
-		Symbol: message.Symbol,
-		Stoch:  make(map[string]*models.Stoch),
+		Symbol:        message.Symbol,
+		Stoch:         make(map[string]*models.Stoch),
+		EngineVersion: s.resolveEngineVersion(message.Symbol),
+		Source:        models.SignalSourceAnalyzer,
+		Freshness:     make(map[string]int64),
 	}
 
+	tradingInterval := s.settings.IntervalFor(s.settings.TradingStrategy)
+	minWarmup := s.settings.MinWarmupCandlesFor(s.settings.TradingStrategy)
+
 	for interval, candles := range message.Candles {
 		if candles == nil {
 			continue
 		}
 
+		if minWarmup > 0 && len(candles.Candles) < minWarmup {
+			s.logger.Info("[Process] not enough warm-up candles yet", zap.String("symbol", message.Symbol), zap.String("interval", interval), zap.Int("have", len(candles.Candles)), zap.Int("need", minWarmup))
+			continue
+		}
+
 		low := make([]float64, len(candles.Candles))
 		high := make([]float64, len(candles.Candles))
 		close := make([]float64, len(candles.Candles))
 
 		for idx, candle := range candles.Candles {
-			l, _ := strconv.ParseFloat(candle.Low, 64)
-			low[idx] = l
+			low[idx] = candle.LowFloat()
+			high[idx] = candle.HighFloat()
+			close[idx] = candle.CloseFloat()
+		}
+
+		tail := candles.Candles[len(candles.Candles)-1]
+
+		if interval == tradingInterval {
+			oscillator.Timing = models.SignalTimingIntrabar
+			if tail.Closed {
+				oscillator.Timing = models.SignalTimingClosed
+			}
+
+			if s.settings.RequireClosedCandle && !tail.Closed {
+				return errors.New("analyze: trading interval candle not closed yet")
+			}
+		}
 
-			h, _ := strconv.ParseFloat(candle.High, 64)
-			high[idx] = h
+		state := s.indicatorState(message.Symbol, interval)
+		rsi, k, d := state.update(tail.OpenTime, high[len(high)-1], low[len(low)-1], close[len(close)-1])
 
-			c, _ := strconv.ParseFloat(candle.Close, 64)
-			close[idx] = c
+		oscillator.Stoch[interval] = &models.Stoch{
+			RSI: rsi,
+			K:   k,
+			D:   d,
 		}
+		oscillator.Freshness[interval] = candles.UpdateTime
+
+		s.broadcast.Publish(broadcast.MarketTopic(message.Symbol, interval), &models.MarketUpdate{
+			Symbol:   message.Symbol,
+			Interval: interval,
+			Candle:   tail,
+			Stoch:    oscillator.Stoch[interval],
+		})
 
-		_, rsi := talib.RSIPeriod(14, close)
-		k, d, _ := talib.KDJ(9, 3, 3, high, low, close)
+		if interval == tradingInterval {
+			oscillator.Regime = cachedIndicator(s, message.Symbol, interval, "regime", "adx14.bb20.2.hurst", tail.OpenTime, func() regime.Regime {
+				return classifyRegime(high, low, close)
+			})
 
-		stoch := &models.Stoch{
-			RSI: rsi[len(rsi)-1],
-			K:   k[len(k)-1],
-			D:   d[len(d)-1],
+			oscillator.ATR = cachedIndicator(s, message.Symbol, interval, "atr", "14", tail.OpenTime, func() float64 {
+				atr := talib.ATR(14, high, low, close)
+				return atr[len(atr)-1]
+			})
 		}
+	}
 
-		oscillator.Stoch[interval] = stoch
+	for _, interval := range symbolIntervals(message.Symbol) {
+		if oscillator.Stoch[interval] == nil {
+			oscillator.MissingIntervals = append(oscillator.MissingIntervals, interval)
+		}
 	}
 
-	if oscillator.Stoch[s.settings.TradingInterval] == nil {
+	if oscillator.Stoch[s.settings.IntervalFor(s.settings.TradingStrategy)] == nil {
 		return errors.New("analyze: trading interval notfound")
 	}
 
-	if !talib.WithinRangeBound(oscillator.Stoch[s.settings.TradingInterval], talib.RangeBoundRecommend) {
+	if !talib.WithinRangeBound(oscillator.Stoch[s.settings.IntervalFor(s.settings.TradingStrategy)], talib.RangeBoundRecommend) {
 		return errors.New("analyze: not ready to trade")
 	}
 
 	var lastUpdate int64
-	if message.Candles[s.settings.TradingInterval] != nil {
-		lastUpdate = message.Candles[s.settings.TradingInterval].UpdateTime
+	if message.Candles[s.settings.IntervalFor(s.settings.TradingStrategy)] != nil {
+		lastUpdate = message.Candles[s.settings.IntervalFor(s.settings.TradingStrategy)].UpdateTime
 	}
 
-	msg := fmt.Sprintf("#%s\t\t\t [%0.2f(s) ago]\n\t%s\n", message.Symbol, float64((time.Now().UnixMilli()-lastUpdate))/1000.0, helpers.ResolvePositionSide(oscillator.GetRSI(s.settings.TradingInterval)))
+	msg := fmt.Sprintf("#%s\t\t\t [%0.2f(s) ago]\n\t%s\n", message.Symbol, float64((time.Now().UnixMilli()-lastUpdate))/1000.0, helpers.ResolvePositionSide(oscillator.GetRSI(s.settings.IntervalFor(s.settings.TradingStrategy))))
 
 	for interval, stoch := range oscillator.Stoch {
 		msg += fmt.Sprintf("\t%03s:\t RSI %2.2f | K %02.2f | D %02.2f\n", strings.ToUpper(interval), stoch.RSI, stoch.K, stoch.D)
 	}
 
-	lastSent, existed := s.cache.SetEX(fmt.Sprintf("signal.sent.%s-%s", message.Symbol, s.settings.TradingInterval), time.Now().UnixMilli())
-	if existed && time.Now().Before(time.UnixMilli(lastSent.(int64)).Add(10*time.Minute)) {
+	msg += fmt.Sprintf("\tregime:\t %s\n", oscillator.Regime)
+
+	oscillator.Confidence = risk.AdjustConfidence(risk.IntervalConfluence(oscillator, s.settings.IntervalFor(s.settings.TradingStrategy)), s.tradingHistory(message.Symbol))
+
+	if !risk.HasRequiredConfluence(oscillator, s.settings.IntervalFor(s.settings.TradingStrategy), s.settings.RequiredConfluenceIntervals) {
+		return errors.New("analyze: required intervals do not confirm trend")
+	}
+
+	s.recordDecisionAudit(message.Symbol, oscillator)
+
+	// Keyed by symbol alone, not symbol+interval, so agreeing signals
+	// across 5m/15m/1h within the window merge into the one decision
+	// above (with boosted Confidence) instead of firing one per interval.
+	bypassed := s.settings.NotificationBypassConfidence > 0 && oscillator.Confidence >= s.settings.NotificationBypassConfidence
+
+	rateLimit := time.Duration(s.settings.NotificationRateLimitMinutes) * time.Minute
+	lastSent, existed := s.cache.SetEX(fmt.Sprintf("signal.sent.%s", message.Symbol), time.Now().UnixMilli())
+	rateLimited := existed && rateLimit > 0 && time.Now().Before(time.UnixMilli(lastSent.(int64)).Add(rateLimit))
+
+	if rateLimited && !bypassed {
 		return errors.New("analyze: signal already sent")
 	}
 
-	expiration, _ := time.ParseDuration(s.settings.TradingInterval)
+	expiration, _ := time.ParseDuration(s.settings.IntervalFor(s.settings.TradingStrategy))
 	if err := s.queue.Push(oscillator, expiration); err != nil {
 		s.logger.Error("[Process] failed to push queue", zap.Error(err))
 	}
 
+	if s.settings.DigestModeEnabled && !bypassed && oscillator.Confidence < s.settings.DigestConfidenceThreshold {
+		s.digest.add(msg)
+		s.logger.Info("[Process] analyze success, buffered to digest", logger.Event{
+			Type: "signal.digested", Symbol: message.Symbol, Severity: logger.SeverityInfo,
+		}.Fields()...)
+		return nil
+	}
+
+	event := logger.Event{Type: "signal.sent", Symbol: message.Symbol, Severity: logger.SeverityInfo}
+
 	err := s.notify.PushNotify(ctx, viper.GetInt64("notify.channels.futures_announcement"), msg)
 	if err != nil {
-		s.logger.Error("[Process] failed to push notification", zap.Error(err))
+		s.logger.Error("[Process] failed to push notification", append(event.Fields(), zap.Error(err))...)
 		return err
 	}
 
-	s.logger.Info("[Process] analyze success, end process", zap.String("symbol", message.Symbol))
+	s.logger.Info("[Process] analyze success, end process", event.Fields()...)
 	return nil
 }
@@ -0,0 +1,23 @@
+package analyzer
+
+import "hash/fnv"
+
+// resolveEngineVersion deterministically routes a symbol to the alternate
+// engine version for the configured rollout percentage, so the same
+// symbol always lands on the same side of the A/B split within a given
+// settings configuration.
+func (s *Analyzer) resolveEngineVersion(symbol string) string {
+	if s.settings.AltEngineVersion == "" || s.settings.AltEngineRolloutPercent <= 0 {
+		return s.settings.EngineVersion
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(symbol))
+	bucket := float64(h.Sum32()%100) + 1
+
+	if bucket <= s.settings.AltEngineRolloutPercent {
+		return s.settings.AltEngineVersion
+	}
+
+	return s.settings.EngineVersion
+}
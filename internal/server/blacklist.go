@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// registerBlacklistCommands wires /blacklist_add and /blacklist_remove
+// Telegram commands to settings, so symbols can be excluded from
+// crawling, analysis, and order placement without a restart.
+func (s *Server) registerBlacklistCommands() {
+	s.notify.Handle("/blacklist_add", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, errors.New("usage: /blacklist_add SYMBOL")
+		}
+
+		symbol := strings.ToUpper(args[0])
+		s.settings.AddBlacklistSymbol(symbol)
+
+		return "blacklisted " + symbol, nil
+	})
+
+	s.notify.Handle("/blacklist_remove", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, errors.New("usage: /blacklist_remove SYMBOL")
+		}
+
+		symbol := strings.ToUpper(args[0])
+		s.settings.RemoveBlacklistSymbol(symbol)
+
+		return "removed " + symbol + " from blacklist", nil
+	})
+
+	s.notify.Handle("/blacklist_list", func(ctx context.Context, args []string) (interface{}, error) {
+		return strings.Join(s.settings.BlacklistedSymbols, ", "), nil
+	})
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// immutableConfigKeys are bound into long-lived objects at New/Start
+// (server.port into the net.Listener, telegram.token into the
+// TelegramBot client) and can't take effect if changed without a
+// restart. startConfigReload reverts any change to one of them rather
+// than silently running with a mismatched live object.
+var immutableConfigKeys = map[string]bool{
+	"server.port":    true,
+	"telegram.token": true,
+}
+
+// startConfigReload watches viper's config file and, on change, logs a
+// diff of every key that changed and reverts any immutableConfigKeys
+// change back to its running value. Every other key (intervals,
+// thresholds, watchlists, ...) is read live by viper.Get* call sites
+// throughout the codebase, so accepting the new value here is enough
+// to apply it at runtime.
+func (s *Server) startConfigReload() {
+	snapshot := flattenConfig(viper.AllSettings())
+
+	viper.OnConfigChange(func(_ fsnotify.Event) {
+		current := flattenConfig(viper.AllSettings())
+
+		rejected := false
+		for key, oldValue := range snapshot {
+			newValue, changed := current[key]
+			if !changed || fmt.Sprint(newValue) == fmt.Sprint(oldValue) {
+				continue
+			}
+
+			if immutableConfigKeys[key] {
+				viper.Set(key, oldValue)
+				current[key] = oldValue
+				rejected = true
+				s.logger.Warn("[ConfigReload] rejected change to immutable key, restart to apply", zap.String("key", key), zap.Any("rejected", newValue), zap.Any("kept", oldValue))
+				continue
+			}
+
+			s.logger.Info("[ConfigReload] applied config change", zap.String("key", key), zap.Any("from", oldValue), zap.Any("to", newValue))
+		}
+
+		for key, newValue := range current {
+			if _, known := snapshot[key]; !known && !immutableConfigKeys[key] {
+				s.logger.Info("[ConfigReload] applied config change", zap.String("key", key), zap.Any("from", nil), zap.Any("to", newValue))
+			}
+		}
+
+		snapshot = current
+
+		if rejected {
+			s.logger.Warn("[ConfigReload] one or more changes were rejected, see above")
+		}
+	})
+
+	viper.WatchConfig()
+}
+
+// flattenConfig flattens a viper.AllSettings() tree into dot-path keys
+// (e.g. "trading.log_json"), matching the key format viper.Get/Set use,
+// so it can be diffed with a plain map comparison.
+func flattenConfig(tree map[string]interface{}) map[string]interface{} {
+	flat := map[string]interface{}{}
+	flattenConfigInto(flat, "", tree)
+	return flat
+}
+
+func flattenConfigInto(flat map[string]interface{}, prefix string, tree map[string]interface{}) {
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := tree[key].(map[string]interface{}); ok {
+			flattenConfigInto(flat, path, nested)
+			continue
+		}
+
+		flat[path] = tree[key]
+	}
+}
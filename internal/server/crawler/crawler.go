@@ -1,15 +1,14 @@
 package crawler
 
 import (
+	"github.com/anvh2/futures-trading/internal/broadcast"
 	"github.com/anvh2/futures-trading/internal/cache"
 	"github.com/anvh2/futures-trading/internal/channel"
 	"github.com/anvh2/futures-trading/internal/logger"
 	"github.com/anvh2/futures-trading/internal/services/binance"
 	"github.com/anvh2/futures-trading/internal/services/telegram"
-)
-
-var (
-	blacklist = map[string]bool{}
+	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/anvh2/futures-trading/internal/state"
 )
 
 type Crawler struct {
@@ -19,7 +18,14 @@ type Crawler struct {
 	marketCache   cache.Market
 	exchangeCache cache.Exchange
 	channel       *channel.Channel
-	quitChannel   chan struct{}
+	broadcast     *broadcast.Hub
+	settings      *settings.Settings
+	// tradingState is read-only here, used to escalate a delisting
+	// notification when the delisted symbol has an open position (see
+	// fetchExchange) and to find the held symbols checkNewsFeed matches
+	// headlines against.
+	tradingState *state.StateManager
+	quitChannel  chan struct{}
 }
 
 func New(
@@ -29,6 +35,9 @@ func New(
 	marketCache cache.Market,
 	exchangeCache cache.Exchange,
 	channel *channel.Channel,
+	broadcast *broadcast.Hub,
+	settings *settings.Settings,
+	tradingState *state.StateManager,
 ) *Crawler {
 	return &Crawler{
 		logger:        logger,
@@ -37,6 +46,9 @@ func New(
 		marketCache:   marketCache,
 		exchangeCache: exchangeCache,
 		channel:       channel,
+		broadcast:     broadcast,
+		settings:      settings,
+		tradingState:  tradingState,
 		quitChannel:   make(chan struct{}),
 	}
 }
@@ -0,0 +1,128 @@
+package crawler
+
+import (
+	"context"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/metrics"
+	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// startIntegrityCheck periodically scans every symbol/interval's
+// cached candles for gaps, duplicates, and out-of-order timestamps,
+// repairing whatever it finds via the same Client.GetCandlesticks REST
+// backfill StartRetry uses after a dropped websocket reconnect. A
+// non-positive CandleIntegrityCheckIntervalMinutes disables it.
+func (s *Crawler) startIntegrityCheck() {
+	interval := time.Duration(s.settings.CandleIntegrityCheckIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.checkCandleIntegrity(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// checkCandleIntegrity scans every symbol/interval the exchange cache
+// tracks against the market cache, repairing a symbol/interval once if
+// any issue is found in it.
+func (s *Crawler) checkCandleIntegrity(ctx context.Context) {
+	for _, symbol := range s.exchangeCache.Symbols() {
+		summary, err := s.marketCache.CandleSummary(symbol)
+		if err != nil {
+			continue
+		}
+
+		for _, interval := range symbolIntervals(symbol) {
+			candles, err := summary.Candles(interval)
+			if err != nil {
+				continue
+			}
+
+			if !s.reportCandleIssues(symbol, interval, candles.Sorted()) {
+				continue
+			}
+
+			s.repairCandles(ctx, symbol, interval)
+		}
+	}
+}
+
+// reportCandleIssues records a CandleIntegrityIssues metric for every
+// duplicate, out-of-order, or gapped candle found in sorted (oldest
+// first), and reports whether any issue was found at all. Gaps are
+// only checked when interval parses as a time.Duration, since Binance
+// intervals like "1M" don't.
+func (s *Crawler) reportCandleIssues(symbol, interval string, sorted []interface{}) bool {
+	found := false
+	step, stepErr := time.ParseDuration(interval)
+
+	var previous *models.Candlestick
+
+	for _, item := range sorted {
+		candle, ok := item.(*models.Candlestick)
+		if !ok {
+			continue
+		}
+
+		if previous != nil {
+			switch {
+			case candle.OpenTime == previous.OpenTime:
+				metrics.CandleIntegrityIssues.WithLabelValues(symbol, interval, "duplicate").Inc()
+				found = true
+
+			case candle.OpenTime < previous.OpenTime:
+				metrics.CandleIntegrityIssues.WithLabelValues(symbol, interval, "out_of_order").Inc()
+				found = true
+
+			case stepErr == nil && candle.OpenTime-previous.OpenTime > step.Milliseconds():
+				metrics.CandleIntegrityIssues.WithLabelValues(symbol, interval, "gap").Inc()
+				found = true
+			}
+		}
+
+		previous = candle
+	}
+
+	return found
+}
+
+// repairCandles re-backfills symbol/interval from
+// Client.GetCandlesticks and replaces its cached window wholesale, the
+// same repair StartRetry performs after a dropped websocket reconnect.
+func (s *Crawler) repairCandles(ctx context.Context, symbol, interval string) {
+	resp, err := s.binance.GetCandlesticks(ctx, symbol, interval, viper.GetInt("chart.candles.limit"), 0, 0)
+	if err != nil {
+		s.logger.Error("[IntegrityCheck] failed to backfill candles", zap.String("symbol", symbol), zap.String("interval", interval), zap.Error(err))
+		return
+	}
+
+	for _, e := range resp {
+		candle := &models.Candlestick{
+			OpenTime:  e.OpenTime,
+			CloseTime: e.CloseTime,
+			Low:       e.Low,
+			High:      e.High,
+			Close:     e.Close,
+		}
+
+		s.marketCache.UpdateSummary(symbol).CreateCandle(interval, candle)
+	}
+
+	metrics.CandleIntegrityRepairs.WithLabelValues(symbol, interval).Inc()
+	s.logger.Info("[IntegrityCheck] repaired candles", zap.String("symbol", symbol), zap.String("interval", interval), zap.Int("total", len(resp)))
+}
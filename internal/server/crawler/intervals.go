@@ -0,0 +1,28 @@
+package crawler
+
+import "github.com/spf13/viper"
+
+// symbolIntervals returns the candle intervals to crawl/stream for
+// symbol, falling back to market.intervals when symbol has no entry
+// under market.symbol_intervals. This lets config keep majors on more
+// intervals (e.g. 1m+5m+1h) while alts stay on fewer (e.g. 15m+1h),
+// reducing websocket load for symbols that don't need the extra ones.
+func symbolIntervals(symbol string) []string {
+	if overrides := viper.GetStringMapStringSlice("market.symbol_intervals"); len(overrides) > 0 {
+		if intervals, ok := overrides[symbol]; ok && len(intervals) > 0 {
+			return intervals
+		}
+	}
+
+	return viper.GetStringSlice("market.intervals")
+}
+
+// hasInterval reports whether interval is present in intervals.
+func hasInterval(intervals []string, interval string) bool {
+	for _, candidate := range intervals {
+		if candidate == interval {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package crawler
+
+import (
+	"context"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/metrics"
+	"go.uber.org/zap"
+)
+
+// startMaintenanceCheck periodically polls Binance's exchange-wide
+// system status and records it on exchangeCache, so
+// safety.TradingStatusRule can pause trading globally during a
+// maintenance window instead of letting every in-flight order fail
+// one at a time. A non-positive ExchangeMaintenanceCheckIntervalSeconds
+// disables it.
+func (s *Crawler) startMaintenanceCheck() {
+	interval := time.Duration(s.settings.ExchangeMaintenanceCheckIntervalSeconds) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.checkMaintenanceStatus(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// checkMaintenanceStatus polls Binance.GetSystemStatus once and updates
+// exchangeCache and the ExchangeMaintenance gauge with the result.
+func (s *Crawler) checkMaintenanceStatus(ctx context.Context) {
+	resp, err := s.binance.GetSystemStatus(ctx)
+	if err != nil {
+		s.logger.Error("[MaintenanceCheck] failed to get system status", zap.Error(err))
+		return
+	}
+
+	maintenance := resp.Status != 0
+
+	if maintenance != s.exchangeCache.Maintenance() {
+		s.logger.Info("[MaintenanceCheck] exchange maintenance status changed", zap.Bool("maintenance", maintenance))
+	}
+
+	s.exchangeCache.SetMaintenance(maintenance)
+
+	if maintenance {
+		metrics.ExchangeMaintenance.Set(1)
+	} else {
+		metrics.ExchangeMaintenance.Set(0)
+	}
+}
@@ -2,6 +2,7 @@ package crawler
 
 import (
 	"context"
+	"fmt"
 	"runtime/debug"
 	"strings"
 	"sync"
@@ -42,6 +43,9 @@ func (s *Crawler) Start() error {
 	s.StartRetry()
 	s.StartConsumption()
 	s.StartNotification()
+	s.startIntegrityCheck()
+	s.startMaintenanceCheck()
+	s.startNewsCheck()
 
 	return nil
 }
@@ -60,8 +64,8 @@ func (s *Crawler) fetchExchange() error {
 			continue
 		}
 
-		if symbol.MarginAsset == "USDT" {
-			if blacklist[symbol.Symbol] {
+		if s.settings.IsMarginAssetAllowed(symbol.MarginAsset) {
+			if s.settings.IsBlacklisted(symbol.Symbol) {
 				continue
 			}
 
@@ -70,21 +74,86 @@ func (s *Crawler) fetchExchange() error {
 
 			selected = append(selected,
 				&exchange.Symbol{
-					Symbol:      symbol.Symbol,
-					Pair:        symbol.Pair,
-					Filters:     filters,
-					MarginAsset: symbol.MarginAsset,
-					BaseAsset:   symbol.BaseAsset,
+					Symbol:         symbol.Symbol,
+					Pair:           symbol.Pair,
+					Filters:        filters,
+					MarginAsset:    symbol.MarginAsset,
+					BaseAsset:      symbol.BaseAsset,
+					QuoteAsset:     symbol.QuoteAsset,
+					OnboardDate:    symbol.OnboardDate,
+					UnderlyingType: symbol.UnderlyingType,
+					Status:         symbol.Status,
 				},
 			)
 		}
 	}
 
+	previous := s.exchangeCache.Symbols()
 	s.exchangeCache.Set(selected)
 	s.logger.Info("[Crawling] cache symbols success", zap.Int("total", len(selected)))
+
+	current := make([]string, len(selected))
+	for idx, symbol := range selected {
+		current[idx] = symbol.Symbol
+	}
+
+	s.reportListingChanges(context.Background(), previous, current)
+
 	return nil
 }
 
+// reportListingChanges diffs previous against current and notifies
+// once per newly listed or delisted symbol, so the operator finds out
+// about exchange-level changes from the same 15-minute fetchExchange
+// poll that already notices them, instead of only after a signal or
+// an order against that symbol starts failing. A delisted symbol with
+// an open position is escalated, since exchangeCache.Set has already
+// dropped it from the cache and nothing will catch it automatically.
+func (s *Crawler) reportListingChanges(ctx context.Context, previous, current []string) {
+	if len(previous) == 0 {
+		// first fetchExchange since startup: nothing to diff against.
+		return
+	}
+
+	previousSet := make(map[string]bool, len(previous))
+	for _, symbol := range previous {
+		previousSet[symbol] = true
+	}
+
+	currentSet := make(map[string]bool, len(current))
+	for _, symbol := range current {
+		currentSet[symbol] = true
+	}
+
+	for _, symbol := range current {
+		if !previousSet[symbol] {
+			s.notifyListingChange(ctx, fmt.Sprintf("🆕 new listing: %s is now tradable", symbol))
+		}
+	}
+
+	for _, symbol := range previous {
+		if currentSet[symbol] {
+			continue
+		}
+
+		msg := fmt.Sprintf("⚠️ delisted: %s is no longer tradable", symbol)
+
+		if s.tradingState != nil {
+			if _, open := s.tradingState.Position(symbol); open {
+				msg = fmt.Sprintf("🚨 delisted WITH AN OPEN POSITION: %s, check /manual_positions now", symbol)
+			}
+		}
+
+		s.notifyListingChange(ctx, msg)
+	}
+}
+
+func (s *Crawler) notifyListingChange(ctx context.Context, msg string) {
+	if err := s.notify.PushNotify(ctx, viper.GetInt64("notify.channels.futures_announcement"), msg); err != nil {
+		s.logger.Error("[Crawling] failed to push listing change notification", zap.String("message", msg), zap.Error(err))
+	}
+}
+
 func (s *Crawler) fetchMarketSummary(ctx context.Context) error {
 	var (
 		wg    = &sync.WaitGroup{}
@@ -105,6 +174,10 @@ func (s *Crawler) fetchMarketSummary(ctx context.Context) error {
 			defer wg.Done()
 
 			for _, symbol := range s.exchangeCache.Symbols() {
+				if !hasInterval(symbolIntervals(symbol), interval) {
+					continue
+				}
+
 				resp, err := s.binance.GetCandlesticks(ctx, symbol, interval, viper.GetInt("chart.candles.limit"), 0, 0)
 				if err != nil {
 					s.logger.Error("[Crawling] failed to get klines data", zap.String("symbol", symbol), zap.String("interval", interval), zap.Error(err))
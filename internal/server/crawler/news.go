@@ -0,0 +1,145 @@
+package crawler
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// rssFeed is the minimal subset of an RSS 2.0 feed startNewsCheck scans
+// for keyword hits. Most exchange-status and crypto-news feeds are
+// published as RSS; encoding/xml parses that directly so this doesn't
+// need a feed-parsing dependency this module doesn't vendor.
+type rssFeed struct {
+	Channel struct {
+		Items []struct {
+			Title       string `xml:"title"`
+			Description string `xml:"description"`
+		} `xml:"item"`
+	} `xml:"channel"`
+}
+
+// startNewsCheck periodically polls settings.NewsFeedURL for headlines
+// mentioning settings.NewsKeywords (e.g. "hack", "delisting", "SEC")
+// alongside a symbol the account currently holds, recording any hit on
+// exchangeCache so safety.NewsKillSwitchRule can pause new entries on
+// it. An empty NewsFeedURL or non-positive NewsCheckIntervalSeconds
+// disables the check.
+func (s *Crawler) startNewsCheck() {
+	if s.settings.NewsFeedURL == "" {
+		return
+	}
+
+	interval := time.Duration(s.settings.NewsCheckIntervalSeconds) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.checkNewsFeed(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// checkNewsFeed fetches settings.NewsFeedURL once and replaces
+// exchangeCache's flagged symbol set with whatever held symbols are
+// mentioned alongside a keyword hit in this poll, so a headline that
+// scrolls off the feed stops flagging its symbol on the next one.
+func (s *Crawler) checkNewsFeed(ctx context.Context) {
+	items, err := s.fetchNewsFeed(ctx)
+	if err != nil {
+		s.logger.Error("[NewsCheck] failed to fetch feed", zap.String("url", s.settings.NewsFeedURL), zap.Error(err))
+		return
+	}
+
+	held := s.tradingState.GetState().Positions
+
+	flagged := make(map[string]string)
+
+	for _, item := range items.Channel.Items {
+		text := strings.ToLower(item.Title + " " + item.Description)
+
+		keyword := matchedKeyword(text, s.settings.NewsKeywords)
+		if keyword == "" {
+			continue
+		}
+
+		for symbol := range held {
+			if _, ok := flagged[symbol]; ok {
+				continue
+			}
+
+			if strings.Contains(text, strings.ToLower(baseAsset(symbol))) {
+				flagged[symbol] = item.Title
+				s.logger.Warn("[NewsCheck] symbol flagged by news feed", zap.String("symbol", symbol), zap.String("keyword", keyword), zap.String("headline", item.Title))
+			}
+		}
+	}
+
+	s.exchangeCache.SetNewsFlags(flagged)
+}
+
+func (s *Crawler) fetchNewsFeed(ctx context.Context) (*rssFeed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.settings.NewsFeedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &rssFeed{}
+	if err := xml.Unmarshal(body, feed); err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+// matchedKeyword returns the first of keywords found in text, or "" if
+// none match.
+func matchedKeyword(text string, keywords []string) string {
+	for _, keyword := range keywords {
+		if strings.Contains(text, strings.ToLower(keyword)) {
+			return keyword
+		}
+	}
+
+	return ""
+}
+
+// baseAsset strips the common margin-asset suffix off a futures symbol
+// (e.g. "BTCUSDT" -> "BTC"), so a headline naming the underlying coin
+// still matches the symbol it's quoted against.
+func baseAsset(symbol string) string {
+	for _, suffix := range []string{"USDT", "BUSD", "USDC"} {
+		if strings.HasSuffix(symbol, suffix) {
+			return strings.TrimSuffix(symbol, suffix)
+		}
+	}
+
+	return symbol
+}
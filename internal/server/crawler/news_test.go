@@ -0,0 +1,37 @@
+package crawler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchedKeyword(t *testing.T) {
+	keywords := []string{"hack", "delisting", "SEC"}
+
+	if got := matchedKeyword(strings.ToLower("exchange confirms a hack drained user funds"), keywords); got != "hack" {
+		t.Errorf("got %q, want %q", got, "hack")
+	}
+
+	if got := matchedKeyword(strings.ToLower("SEC opens investigation into issuer"), keywords); got != "SEC" {
+		t.Errorf("got %q, want %q", got, "SEC")
+	}
+
+	if got := matchedKeyword(strings.ToLower("nothing notable happened today"), keywords); got != "" {
+		t.Errorf("got %q, want no match", got)
+	}
+}
+
+func TestBaseAsset(t *testing.T) {
+	cases := map[string]string{
+		"BTCUSDT": "BTC",
+		"ETHBUSD": "ETH",
+		"SOLUSDC": "SOL",
+		"UNKNOWN": "UNKNOWN",
+	}
+
+	for symbol, want := range cases {
+		if got := baseAsset(symbol); got != want {
+			t.Errorf("baseAsset(%q) = %q, want %q", symbol, got, want)
+		}
+	}
+}
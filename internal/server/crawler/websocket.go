@@ -5,9 +5,9 @@ import (
 	"runtime/debug"
 
 	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/broadcast"
 	"github.com/anvh2/futures-trading/internal/cache/errors"
 	"github.com/anvh2/futures-trading/internal/models"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
@@ -15,12 +15,19 @@ func (s *Crawler) StartConsumption() error {
 	ready := make(chan bool)
 
 	go func() {
-		for _, interval := range viper.GetStringSlice("market.intervals") {
-			pair := make(map[string]string, len(s.exchangeCache.Symbols()))
-			for _, symbol := range s.exchangeCache.Symbols() {
+		pairsByInterval := make(map[string]map[string]string)
+		for _, symbol := range s.exchangeCache.Symbols() {
+			for _, interval := range symbolIntervals(symbol) {
+				pair, ok := pairsByInterval[interval]
+				if !ok {
+					pair = make(map[string]string)
+					pairsByInterval[interval] = pair
+				}
 				pair[symbol] = interval
 			}
+		}
 
+		for _, pair := range pairsByInterval {
 			go func() {
 				defer func() {
 					if r := recover(); r != nil {
@@ -97,8 +104,10 @@ func (s *Crawler) handleCandlesConsumption(event *futures.WsKlineEvent) {
 		lastCandle.Close = event.Kline.Close
 		lastCandle.High = event.Kline.High
 		lastCandle.Low = event.Kline.Low
+		lastCandle.Closed = event.Kline.IsFinal
 
 		chart.UpdateCandle(event.Kline.Interval, idx, lastCandle)
+		s.publishCandle(event.Symbol, event.Kline.Interval, lastCandle)
 		return
 	}
 
@@ -109,9 +118,23 @@ func (s *Crawler) handleCandlesConsumption(event *futures.WsKlineEvent) {
 		Low:       event.Kline.Low,
 		High:      event.Kline.High,
 		Close:     event.Kline.Close,
+		Closed:    event.Kline.IsFinal,
 	}
 
 	chart.CreateCandle(event.Kline.Interval, candle)
+	s.publishCandle(event.Symbol, event.Kline.Interval, candle)
+}
+
+// publishCandle fans symbol/interval's latest candle out to
+// broadcast.Hub subscribers, so other in-process consumers (and,
+// eventually, external Subscribe RPC callers) see it without reading
+// marketCache themselves.
+func (s *Crawler) publishCandle(symbol, interval string, candle *models.Candlestick) {
+	s.broadcast.Publish(broadcast.MarketTopic(symbol, interval), &models.MarketUpdate{
+		Symbol:   symbol,
+		Interval: interval,
+		Candle:   candle,
+	})
 }
 
 func (s *Crawler) handleConsumeError(err error) {
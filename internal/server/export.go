@@ -0,0 +1,136 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/anvh2/futures-trading/internal/cache/errors"
+	"github.com/anvh2/futures-trading/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCandlesExportPath   = "/v1/candles/export"
+	defaultEquityExportPath    = "/v1/equity/export"
+	defaultPositionsExportPath = "/v1/positions/export"
+)
+
+// candlesExportHandler writes the market cache's current candles for
+// one symbol/interval out as CSV, oldest candle first, for offline
+// research in pandas. symbol and interval are required query
+// parameters, e.g. /v1/candles/export?symbol=BTCUSDT&interval=1h.
+//
+// Parquet output, also asked for alongside CSV, needs a parquet-writing
+// dependency this module doesn't vendor and this environment has no
+// network access to fetch; only CSV is implemented here.
+func (s *Server) candlesExportHandler(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	symbol := r.URL.Query().Get("symbol")
+	interval := r.URL.Query().Get("interval")
+
+	if symbol == "" || interval == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	summary, err := s.marketCache.CandleSummary(symbol)
+	if err != nil {
+		s.writeExportError(w, err)
+		return
+	}
+
+	candles, err := summary.Candles(interval)
+	if err != nil {
+		s.writeExportError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s_%s.csv", symbol, interval))
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	writer.Write([]string{"open_time", "close_time", "open", "high", "low", "close", "volume"})
+
+	for _, data := range candles.Sorted() {
+		candle, ok := data.(*models.Candlestick)
+		if !ok {
+			continue
+		}
+
+		writer.Write([]string{
+			strconv.FormatInt(candle.OpenTime, 10),
+			strconv.FormatInt(candle.CloseTime, 10),
+			candle.Open,
+			candle.High,
+			candle.Low,
+			candle.Close,
+			candle.Volume,
+		})
+	}
+}
+
+// equityExportHandler writes the persisted account equity curve's
+// sample history out as CSV, oldest sample first, for the drawdown/VaR
+// and equity-curve charting offline research that only a single
+// point-in-time equity figure can't support, see
+// risk.EquityCurve.Samples/HistoricalVaR.
+func (s *Server) equityExportHandler(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	curve := s.tradingState.Equity()
+	if curve == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=equity.csv")
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	writer.Write([]string{"timestamp", "equity", "drawdown_percent"})
+
+	for _, sample := range curve.Samples {
+		drawdown := 0.0
+		if curve.Peak > 0 && sample.Equity < curve.Peak {
+			drawdown = (curve.Peak - sample.Equity) / curve.Peak * 100
+		}
+
+		writer.Write([]string{
+			strconv.FormatInt(sample.Timestamp, 10),
+			strconv.FormatFloat(sample.Equity, 'f', -1, 64),
+			strconv.FormatFloat(drawdown, 'f', 4, 64),
+		})
+	}
+}
+
+// positionsExportHandler writes every currently tracked
+// state.PositionRecord out as JSON, SL/TP intents and order event
+// history included, for disaster recovery: the output of this endpoint
+// is exactly what /positions_import re-verifies against the exchange
+// and re-attaches management to on a fresh instance after the original
+// trading.state_path file is lost. JSON rather than CSV, unlike the
+// other export handlers, since PositionRecord nests Events.
+func (s *Server) positionsExportHandler(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	positions := s.tradingState.GetState().Positions
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", "attachment; filename=positions.json")
+
+	if err := json.NewEncoder(w).Encode(positions); err != nil {
+		s.logger.Error("[Export] failed to encode positions", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) writeExportError(w http.ResponseWriter, err error) {
+	switch err {
+	case errors.ErrorChartNotFound, errors.ErrorCandlesNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
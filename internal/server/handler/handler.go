@@ -1,5 +1,11 @@
 package handler
 
+// Handler implements signal.SignalServiceServer. A streaming Subscribe
+// RPC that fans internal/broadcast.Hub's candle/indicator updates out
+// to external callers (see broadcast.MarketTopic, crawler.publishCandle,
+// analyzer.process) needs a new streaming method on SignalService,
+// which requires regenerating service.pb.go/service_grpc.pb.go from
+// api/v1/signal/service.proto; not done here.
 type Handler struct {
 }
 
@@ -0,0 +1,34 @@
+package heartbeat
+
+import (
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/services/binance"
+	"github.com/anvh2/futures-trading/internal/settings"
+)
+
+// Heartbeat periodically reports system status and open position count
+// to HeartbeatURL, so an external watchdog (e.g. healthchecks.io) can
+// detect a dead bot that's still holding positions.
+type Heartbeat struct {
+	logger      *logger.Logger
+	binance     *binance.Binance
+	settings    *settings.Settings
+	quitChannel chan struct{}
+}
+
+func New(
+	logger *logger.Logger,
+	binance *binance.Binance,
+	settings *settings.Settings,
+) *Heartbeat {
+	return &Heartbeat{
+		logger:      logger,
+		binance:     binance,
+		settings:    settings,
+		quitChannel: make(chan struct{}),
+	}
+}
+
+func (s *Heartbeat) Stop() {
+	close(s.quitChannel)
+}
@@ -0,0 +1,95 @@
+package heartbeat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// status is the JSON body POSTed to HeartbeatURL on every ping.
+type status struct {
+	Status        string `json:"status"`
+	OpenPositions int    `json:"open_positions"`
+	Timestamp     string `json:"timestamp"`
+}
+
+// Start fires a ping every HeartbeatIntervalSeconds until Stop is
+// called. A non-positive interval disables the heartbeat.
+func (s *Heartbeat) Start() error {
+	interval := time.Duration(s.settings.HeartbeatIntervalSeconds) * time.Second
+	if interval <= 0 {
+		return nil
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.ping(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// ping reports the current system status and open position count to
+// HeartbeatURL. A failure to count open positions is reported as a
+// "degraded" status rather than skipping the ping, since a watchdog
+// should be told the bot is alive but unable to see its own book.
+func (s *Heartbeat) ping(ctx context.Context) {
+	if s.settings.HeartbeatURL == "" {
+		return
+	}
+
+	current := status{Status: "ok", Timestamp: time.Now().UTC().Format(time.RFC3339)}
+
+	positions, err := s.binance.GetOpenPositions(ctx)
+	if err != nil {
+		s.logger.Error("[Heartbeat] failed to get open positions", zap.Error(err))
+		current.Status = "degraded"
+	} else {
+		current.OpenPositions = len(positions)
+	}
+
+	if err := s.send(ctx, current); err != nil {
+		s.logger.Error("[Heartbeat] failed to send ping", zap.Error(err))
+	}
+}
+
+func (s *Heartbeat) send(ctx context.Context, current status) error {
+	body, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.settings.HeartbeatURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("heartbeat: ping rejected with status %s", resp.Status)
+	}
+
+	return nil
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -37,7 +36,7 @@ func (s *Server) httpServe(ctx context.Context, l net.Listener) error {
 
 	// register handler
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-	endPoint := fmt.Sprintf("localhost:%d", viper.GetInt("server.port"))
+	endPoint := fmt.Sprintf("localhost:%d", s.cfg.Server.Port)
 
 	err := s.register.http(ctx, mux, endPoint, opts)
 	if err != nil {
@@ -50,10 +49,18 @@ func (s *Server) httpServe(ctx context.Context, l net.Listener) error {
 	mux.HandlePath(http.MethodGet, defaultMetricsPath, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
 		promhttp.Handler().ServeHTTP(w, r)
 	})
+	mux.HandlePath(http.MethodGet, defaultSymbolStatsPath, s.symbolStatsHandler)
+	mux.HandlePath(http.MethodGet, defaultRejectionsStatsPath, s.rejectionsStatsHandler)
+	mux.HandlePath(http.MethodGet, defaultCandlesExportPath, s.candlesExportHandler)
+	mux.HandlePath(http.MethodGet, defaultEquityExportPath, s.equityExportHandler)
+	mux.HandlePath(http.MethodGet, defaultPositionsExportPath, s.positionsExportHandler)
+	mux.HandlePath(http.MethodGet, defaultSlippageStatsPath, s.slippageStatsHandler)
+	mux.HandlePath(http.MethodGet, defaultStatusPath, s.statusHandler)
 
 	// add middlewares
 	var handler http.Handler
 	handler = mux
+	handler = s.withWebhookAuth(handler)
 
 	server := &http.Server{Handler: handler}
 
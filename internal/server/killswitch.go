@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// killSwitchPollInterval trades promptness against how hard the
+// kill-switch watcher hits the filesystem; a few seconds is fast enough
+// to count as a "seconds" emergency stop without busy-polling.
+const killSwitchPollInterval = 3 * time.Second
+
+// startKillSwitch polls settings.KillSwitchFilePath/KillSwitchEnvVar
+// and, once tripped, disables trading and notifies once — a
+// last-resort emergency stop for when the API and Telegram command
+// interface are unreachable, see internal/safety.KillSwitch.
+func (s *Server) startKillSwitch() {
+	ticker := time.NewTicker(killSwitchPollInterval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if !s.killSwitch.Tripped() || !s.settings.TradingEnabled {
+					continue
+				}
+
+				s.settings.TradingEnabled = false
+
+				event := logger.Event{Type: "kill_switch.tripped", Severity: logger.SeverityCritical}
+				s.logger.Warn("[KillSwitch] emergency stop triggered, trading disabled", event.Fields()...)
+
+				if err := s.notify.PushNotify(context.Background(), viper.GetInt64("notify.channels.futures_announcement"), "EMERGENCY STOP: kill-switch triggered, trading disabled"); err != nil {
+					s.logger.Error("[KillSwitch] failed to push notification", append(event.Fields(), zap.Error(err))...)
+				}
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	binancew "github.com/anvh2/futures-trading/internal/services/binance"
+)
+
+// isPositionOpened reports whether position currently holds any
+// quantity, mirroring orderer.isPosititionOpened (kept as a separate
+// copy since that one is unexported to its own package).
+func isPositionOpened(position *binancew.Position) bool {
+	return position.EntryPrice != "" && position.EntryPrice != "0.0"
+}
+
+// unmanagedPositions returns every open exchange position this system
+// neither has a state.PositionRecord for nor has been told to ignore via
+// /manual_positions_ignore, i.e. one that would otherwise sit invisible
+// to evaluateExits, the safety package, and risk accounting.
+func (s *Server) unmanagedPositions(ctx context.Context) ([]*binancew.Position, error) {
+	positions, err := s.binance.GetPositionRisk(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	unmanaged := make([]*binancew.Position, 0, len(positions))
+	for _, position := range positions {
+		if !isPositionOpened(position) {
+			continue
+		}
+
+		if _, tracked := s.tradingState.Position(position.Symbol); tracked {
+			continue
+		}
+
+		if s.settings.IsManualPositionIgnored(position.Symbol) {
+			continue
+		}
+
+		unmanaged = append(unmanaged, position)
+	}
+
+	return unmanaged, nil
+}
+
+// registerManualPositionCommands wires Telegram commands for the manual
+// position adoption workflow: /manual_positions lists exchange
+// positions reconciliation found with no bot-owned state.PositionRecord,
+// /manual_positions_adopt brings one under management with an explicit
+// stop/take-profit, and /manual_positions_ignore leaves one out of risk
+// accounting deliberately instead of it resurfacing every time.
+func (s *Server) registerManualPositionCommands() {
+	s.notify.Handle("/manual_positions", func(ctx context.Context, args []string) (interface{}, error) {
+		unmanaged, err := s.unmanagedPositions(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(unmanaged) == 0 {
+			return "no unmanaged positions", nil
+		}
+
+		lines := make([]string, 0, len(unmanaged))
+		for _, position := range unmanaged {
+			lines = append(lines, fmt.Sprintf("%s %s qty=%s entry=%s", position.Symbol, position.PositionSide, position.PositionAmt, position.EntryPrice))
+		}
+
+		return strings.Join(lines, "\n"), nil
+	})
+
+	s.notify.Handle("/manual_positions_adopt", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) < 3 {
+			return nil, errors.New("usage: /manual_positions_adopt SYMBOL STOP_PRICE TAKE_PROFIT_PRICE")
+		}
+
+		symbol := strings.ToUpper(args[0])
+		stopPrice, takeProfitPrice := args[1], args[2]
+
+		positions, err := s.binance.GetPositionRisk(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, position := range positions {
+			if position.Symbol != symbol || !isPositionOpened(position) {
+				continue
+			}
+
+			s.tradingState.AdoptPosition(symbol, position.PositionSide, helpers.StringToFloat(position.PositionAmt), position.EntryPrice, stopPrice, "", takeProfitPrice)
+			s.settings.UnignoreManualPosition(symbol)
+
+			return "adopted " + symbol, nil
+		}
+
+		return nil, fmt.Errorf("no open position found for %s", symbol)
+	})
+
+	s.notify.Handle("/manual_positions_ignore", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, errors.New("usage: /manual_positions_ignore SYMBOL")
+		}
+
+		symbol := strings.ToUpper(args[0])
+		s.settings.IgnoreManualPosition(symbol)
+
+		return "ignoring " + symbol + " for risk accounting", nil
+	})
+}
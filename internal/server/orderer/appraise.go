@@ -7,11 +7,12 @@ import (
 	"github.com/adshao/go-binance/v2/futures"
 	"github.com/anvh2/futures-trading/internal/helpers"
 	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/risk"
 	"github.com/anvh2/futures-trading/internal/settings"
 	"go.uber.org/zap"
 )
 
-func (s *Orderer) appraise(ctx context.Context, symbol string, positionSide futures.PositionSideType) (*models.Price, error) {
+func (s *Orderer) appraise(ctx context.Context, symbol string, positionSide futures.PositionSideType, confidence float64) (*models.Price, error) {
 	leverageBrackets, err := s.binance.GetLeverageBracket(ctx, symbol)
 	if err != nil {
 		s.logger.Error("[Appraise] faile to get leverage bracket", zap.String("symbol", symbol), zap.Error(err))
@@ -20,13 +21,18 @@ func (s *Orderer) appraise(ctx context.Context, symbol string, positionSide futu
 
 	leverage := s.settings.GetPreferLeverage(leverageBrackets)
 
+	if capped := s.settings.MaxLeverageFor(s.settings.TradingStrategy, symbol, leverage); capped < leverage {
+		s.logger.Info("[Appraise] leverage capped", zap.String("symbol", symbol), zap.Int("recommended", leverage), zap.Int("applied", capped))
+		leverage = capped
+	}
+
 	symbolPrice, err := s.binance.GetCurrentPrice(ctx, symbol)
 	if err != nil {
 		s.logger.Error("[Appraise] failed to get current symbol price", zap.String("symbol", symbol), zap.Error(err))
 		return nil, err
 	}
 
-	candles, err := s.binance.GetCandlesticks(ctx, symbol, s.settings.TradingInterval, 2, 0, 0)
+	candles, err := s.binance.GetCandlesticks(ctx, symbol, s.settings.IntervalFor(s.settings.TradingStrategy), 2, 0, 0)
 	if err != nil {
 		s.logger.Error("[Appraise] failed to get candles", zap.String("symbol", symbol), zap.Error(err))
 		return nil, err
@@ -36,7 +42,44 @@ func (s *Orderer) appraise(ctx context.Context, symbol string, positionSide futu
 		return nil, errors.New("orders: len of candles not enough")
 	}
 
-	price := &models.Price{}
+	price := &models.Price{Leverage: leverage}
+
+	// tradingCost scales down with s.deleverageMultiplier as the account
+	// sits in drawdown, so position size shrinks against true account
+	// equity instead of a fixed USD amount, see equity.go. It also
+	// scales down with s.cluster's size multiple, so a burst of entries
+	// across correlated symbols (e.g. BTC, ETH, SOL within the same few
+	// minutes) is sized as one combined exposure instead of stacking
+	// independent full-sized positions on what is, in practice, the
+	// same market move. SizeMultiple only previews the multiple this
+	// entry would get; open.go commits it once the position is actually
+	// opened.
+	clusterMultiple := s.cluster.SizeMultiple(symbol)
+	if clusterMultiple < 1 {
+		s.logger.Info("[Appraise] position size reduced for correlated cluster", zap.String("symbol", symbol), zap.Float64("multiple", clusterMultiple))
+	}
+
+	tradingCost := s.settings.TradingCost * s.deleverageMultiplier() * clusterMultiple
+
+	// sizer applies settings.PositionSizingMode on top of the scaling
+	// above, e.g. scaling tradingCost further by signal confidence
+	// (SizingModeConfidence) or a rolling Kelly fraction
+	// (SizingModeKelly) instead of risking the same amount on every
+	// signal regardless of how strong it is.
+	sizingMode := risk.SizingMode(s.settings.PositionSizingMode)
+
+	var history *models.TradingHistory
+	if sizingMode == risk.SizingModeKelly {
+		history = s.tradingHistory(symbol)
+	}
+
+	sizer := risk.NewSizer(sizingMode, history)
+
+	sized, err := sizer.Size(tradingCost, confidence)
+	if err != nil {
+		s.logger.Info("[Appraise] sizer fell back to unscaled trading cost", zap.String("symbol", symbol), zap.Error(err))
+	}
+	tradingCost = sized
 
 	// switch trading_strategy
 	switch s.settings.TradingStrategy {
@@ -50,7 +93,7 @@ func (s *Orderer) appraise(ctx context.Context, symbol string, positionSide futu
 				price.Entry = current * 1.01
 			}
 
-			price.Quantity = s.settings.TradingCost * float64(leverage) / price.Entry
+			price.Quantity = tradingCost * float64(leverage) / price.Entry
 			price.Profit = price.Entry - s.settings.ShortPNL.DesiredProfit/price.Quantity
 			price.Loss = price.Entry - s.settings.ShortPNL.DesiredLoss/price.Quantity
 
@@ -62,13 +105,22 @@ func (s *Orderer) appraise(ctx context.Context, symbol string, positionSide futu
 				price.Entry = current * 0.99
 			}
 
-			price.Quantity = s.settings.TradingCost * float64(leverage) / price.Entry
+			price.Quantity = tradingCost * float64(leverage) / price.Entry
 			price.Profit = s.settings.LongPNL.DesiredProfit/price.Quantity + price.Entry
 			price.Loss = s.settings.LongPNL.DesiredLoss/price.Quantity + price.Entry
 		}
 
 	case settings.TradingStrategyDollarCostAveraging:
 		return nil, errors.New("orders: not implement for dca strategy")
+
+	case settings.TradingStrategyFundingWindowScalp:
+		// Entry is just the current price: the strategy's edge comes from
+		// catching the funding print while it's still extreme, not from
+		// waiting for a better fill the way InstantNoodles does. Profit/
+		// Loss stay unset; create builds its stop/target off
+		// strategy.Plan's ATR-sized distances instead.
+		price.Entry = helpers.StringToFloat(symbolPrice.Price)
+		price.Quantity = tradingCost * float64(leverage) / price.Entry
 	}
 
 	return price, nil
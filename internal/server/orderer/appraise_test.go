@@ -38,7 +38,7 @@ func TestAppraise(t *testing.T) {
 				binance:  _binanceTestnetInst,
 				settings: settings.DefaultSettings,
 			}
-			price, err := order.appraise(context.Background(), test.symbol, test.positionSide)
+			price, err := order.appraise(context.Background(), test.symbol, test.positionSide, 1)
 			assert.Equal(t, test.expectedErr, err)
 			fmt.Println(price.String())
 		})
@@ -0,0 +1,82 @@
+package orderer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	binancew "github.com/anvh2/futures-trading/internal/services/binance"
+	"go.uber.org/zap"
+)
+
+func commissionRateCacheKey(symbol string) string {
+	return fmt.Sprintf("commission.%s", symbol)
+}
+
+// startCommissionTracking periodically refreshes every tracked symbol's
+// actual maker/taker commission rate from Client.GetCommissionRate, so
+// fee-aware break-even stops and PnL accounting reflect volume/BNB fee
+// discounts instead of assuming the default tier. A non-positive
+// CommissionRateRefreshMinutes disables it.
+func (s *Orderer) startCommissionTracking() {
+	interval := time.Duration(s.settings.CommissionRateRefreshMinutes) * time.Minute
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		s.updateCommissionRates(context.Background())
+
+		for {
+			select {
+			case <-ticker.C:
+				s.updateCommissionRates(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// updateCommissionRates refreshes the cached commission rate for every
+// symbol the exchange cache tracks.
+func (s *Orderer) updateCommissionRates(ctx context.Context) {
+	for _, symbol := range s.exchangeCache.Symbols() {
+		rate, err := s.binance.GetCommissionRate(ctx, symbol)
+		if err != nil {
+			s.logger.Error("[CommissionTracking] failed to get commission rate", zap.String("symbol", symbol), zap.Error(err))
+			continue
+		}
+
+		s.cache.Set(commissionRateCacheKey(symbol), rate)
+	}
+}
+
+// commissionRate returns symbol's maker and taker commission rate,
+// falling back to half of settings.BreakEvenFeeBufferFraction each
+// until updateCommissionRates has fetched an actual rate for it.
+func (s *Orderer) commissionRate(symbol string) (maker, taker float64) {
+	fallback := float64(s.settings.BreakEvenFeeBufferFraction) / 2
+
+	rate, _ := s.cache.Get(commissionRateCacheKey(symbol)).(*binancew.CommissionRate)
+	if rate == nil {
+		return fallback, fallback
+	}
+
+	maker = helpers.StringToFloat(rate.MakerCommissionRate)
+	taker = helpers.StringToFloat(rate.TakerCommissionRate)
+
+	if maker <= 0 {
+		maker = fallback
+	}
+	if taker <= 0 {
+		taker = fallback
+	}
+
+	return maker, taker
+}
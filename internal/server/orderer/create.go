@@ -3,15 +3,18 @@ package orderer
 import (
 	"context"
 	"errors"
+	"math"
 
 	"github.com/adshao/go-binance/v2/futures"
 	"github.com/anvh2/futures-trading/internal/helpers"
 	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/risk"
 	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/anvh2/futures-trading/internal/strategy"
 	"github.com/anvh2/futures-trading/internal/talib"
 )
 
-func (s *Orderer) create(ctx context.Context, symbol string, stoch *models.Stoch) ([]*models.Order, error) {
+func (s *Orderer) create(ctx context.Context, symbol string, stoch *models.Stoch, atr float64, confidence float64) ([]*models.Order, error) {
 	if stoch == nil {
 		return nil, errors.New("orders: empty stoch")
 	}
@@ -39,7 +42,7 @@ func (s *Orderer) create(ctx context.Context, symbol string, stoch *models.Stoch
 		closeSide = futures.SideTypeSell
 	}
 
-	price, err := s.appraise(ctx, symbol, positionSide)
+	price, err := s.appraise(ctx, symbol, positionSide, confidence)
 
 	exchange, err := s.exchangeCache.Get(symbol)
 	if err != nil {
@@ -56,6 +59,9 @@ func (s *Orderer) create(ctx context.Context, symbol string, stoch *models.Stoch
 		return nil, err
 	}
 
+	// percentFilter is optional: not every symbol enforces a PERCENT_PRICE band.
+	percentFilter, _ := exchange.GetPercentPriceFilter()
+
 	var orders = []*models.Order{}
 
 	switch s.settings.TradingStrategy {
@@ -158,11 +164,163 @@ func (s *Orderer) create(ctx context.Context, symbol string, stoch *models.Stoch
 				NewOrderRespType: futures.NewOrderRespTypeRESULT,
 			},
 		}
+
+	case settings.TradingStrategyFundingWindowScalp:
+		orders, err = s.createFundingWindowScalp(ctx, symbol, positionSide, sideType, closeSide, price, atr, priceFilter.TickSize, lotFilter.StepSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, order := range orders {
+		if order.OrderType != futures.OrderTypeTakeProfitMarket && order.OrderType != futures.OrderTypeStopMarket {
+			continue
+		}
+
+		stopPrice, err := validateStopPrice(price.Entry, helpers.StringToFloat(order.StopPrice), percentFilter, priceFilter.TickSize)
+		if err != nil {
+			return nil, err
+		}
+
+		minDistance := helpers.StringToFloat(priceFilter.TickSize) * s.settings.MinStopDistanceTicks
+
+		if widened := risk.WidenStopDistance(price.Entry, stopPrice, minDistance); widened != stopPrice {
+			// Only the stop-loss order's dollar risk (distance times
+			// quantity) needs to stay constant; widening the
+			// take-profit trigger only changes reward, not risk.
+			if order.OrderType == futures.OrderTypeStopMarket {
+				originalDistance := math.Abs(stopPrice - price.Entry)
+				newDistance := math.Abs(widened - price.Entry)
+
+				if originalDistance > 0 && newDistance > 0 {
+					quantity := helpers.StringToFloat(order.Quantity) * originalDistance / newDistance
+					order.Quantity = helpers.AlignQuantityToString(quantity, lotFilter.StepSize)
+				}
+			}
+
+			stopPrice = widened
+		}
+
+		order.StopPrice = helpers.AlignPriceToString(stopPrice, priceFilter.TickSize)
+	}
+
+	cfg := risk.StopConfig{
+		RequireStop:                s.settings.RequireStopLoss,
+		MinRiskRewardRatio:         s.settings.MinRiskRewardRatio,
+		MaxStopDistanceATRMultiple: s.settings.StopDistanceATRMultipleFor(s.settings.TradingStrategy),
+	}
+
+	var stopPrice, targetPrice float64
+	if idx := stopOrderIndex(orders); idx >= 0 {
+		stopPrice = helpers.StringToFloat(orders[idx].StopPrice)
+	}
+	if idx := takeProfitOrderIndex(orders); idx >= 0 {
+		targetPrice = helpers.StringToFloat(orders[idx].StopPrice)
+	}
+
+	if err := risk.ValidateStops(cfg, price.Entry, stopPrice, targetPrice, atr); err != nil {
+		return nil, err
 	}
 
 	return orders, nil
 }
 
+// createFundingWindowScalp builds the entry/take-profit/stop-loss orders
+// for TradingStrategyFundingWindowScalp: it asks the registered
+// strategy.Strategy whether the symbol's current funding print is
+// extreme enough to fade, requires its resolved side to agree with the
+// oscillator-resolved positionSide (so the scalp only fires when
+// momentum and the funding print agree), and checks the strategy's own
+// risk budget before sizing orders off strategy.Plan's ATR distances.
+func (s *Orderer) createFundingWindowScalp(ctx context.Context, symbol string, positionSide futures.PositionSideType, sideType, closeSide futures.SideType, price *models.Price, atr float64, tickSize, stepSize string) ([]*models.Order, error) {
+	built, err := strategy.Build(s.settings.TradingStrategy, s.settings)
+	if err != nil {
+		return nil, err
+	}
+
+	premium, err := s.binance.GetPremiumIndex(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, ok := built.Evaluate(&strategy.Input{
+		Symbol:      symbol,
+		Entry:       price.Entry,
+		ATR:         atr,
+		FundingRate: helpers.StringToFloat(premium.LastFundingRate),
+	})
+	if !ok {
+		return nil, errors.New("orders: funding print not extreme enough for a scalp entry")
+	}
+
+	if plan.Side != string(positionSide) {
+		return nil, errors.New("orders: funding print direction disagrees with the oscillator signal")
+	}
+
+	if !s.fundingScalpThrottle.Allow(symbol) {
+		return nil, errors.New("orders: funding scalp risk budget exhausted")
+	}
+
+	stopPrice := price.Entry - plan.StopDistance
+	targetPrice := price.Entry + plan.TargetDistance
+	if positionSide == futures.PositionSideTypeShort {
+		stopPrice = price.Entry + plan.StopDistance
+		targetPrice = price.Entry - plan.TargetDistance
+	}
+
+	quantity := helpers.AlignQuantityToString(price.Quantity, stepSize)
+
+	return []*models.Order{
+		{
+			Symbol:           symbol,
+			Side:             sideType,
+			PositionSide:     positionSide,
+			OrderType:        futures.OrderTypeLimit,
+			TimeInForce:      futures.TimeInForceTypeGTC,
+			Quantity:         quantity,
+			Price:            helpers.AlignPriceToString(price.Entry, tickSize),
+			WorkingType:      futures.WorkingTypeMarkPrice,
+			NewOrderRespType: futures.NewOrderRespTypeRESULT,
+		},
+		// take profit
+		{
+			Symbol:           symbol,
+			Side:             closeSide,
+			PositionSide:     positionSide,
+			OrderType:        futures.OrderTypeTakeProfitMarket,
+			TimeInForce:      futures.TimeInForceTypeGTC,
+			Quantity:         quantity,
+			StopPrice:        helpers.AlignPriceToString(targetPrice, tickSize),
+			WorkingType:      futures.WorkingTypeMarkPrice,
+			NewOrderRespType: futures.NewOrderRespTypeRESULT,
+		},
+		// stop loss
+		{
+			Symbol:           symbol,
+			Side:             closeSide,
+			PositionSide:     positionSide,
+			OrderType:        futures.OrderTypeStopMarket,
+			TimeInForce:      futures.TimeInForceTypeGTC,
+			Quantity:         quantity,
+			StopPrice:        helpers.AlignPriceToString(stopPrice, tickSize),
+			WorkingType:      futures.WorkingTypeMarkPrice,
+			NewOrderRespType: futures.NewOrderRespTypeRESULT,
+		},
+	}, nil
+}
+
+// Simulate runs stoch/atr through the exact order construction,
+// exchange-filter alignment, and risk validation create uses before
+// opening a real position, without submitting anything to the
+// exchange. Useful for a manual sanity check of what a hypothetical
+// signal would produce.
+func (s *Orderer) Simulate(ctx context.Context, symbol string, stoch *models.Stoch, atr float64, confidence float64) ([]*models.Order, error) {
+	if stoch == nil {
+		return nil, errors.New("orders: empty stoch")
+	}
+	return s.create(ctx, symbol, stoch, atr, confidence)
+}
+
 func calculateQuantity(price, amount float64) float64 {
 	return amount / price
 }
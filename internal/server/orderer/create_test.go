@@ -74,7 +74,7 @@ func TestCreate(t *testing.T) {
 				exchangeCache: test.exchange,
 			}
 
-			orders, err := order.create(context.Background(), test.symbol, test.stoch)
+			orders, err := order.create(context.Background(), test.symbol, test.stoch, 0, 1)
 			assert.Equal(t, test.expectedErr, err)
 			b, _ := json.Marshal(orders)
 			fmt.Println(string(b))
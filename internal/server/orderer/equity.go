@@ -0,0 +1,110 @@
+package orderer
+
+import (
+	"context"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"go.uber.org/zap"
+)
+
+// stableAssets are margin assets valued 1:1 against USD, so no spot
+// conversion is needed to fold their balance into account equity.
+var stableAssets = map[string]bool{
+	"USDT":  true,
+	"USDC":  true,
+	"BUSD":  true,
+	"FDUSD": true,
+}
+
+// startEquityTracking periodically refreshes the account's true equity
+// from Client.GetBalances, converting non-USD collateral (e.g. BNB,
+// BTC) via its current price, and feeds it into
+// state.StateManager.UpdateEquity, so MaxDrawdownPercent deleverages
+// against the account's actual balance rather than assuming it's held
+// entirely in USDT. A non-positive EquityTrackingIntervalSeconds
+// disables it.
+func (s *Orderer) startEquityTracking() {
+	interval := time.Duration(s.settings.EquityTrackingIntervalSeconds) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.updateEquity(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// updateEquity sums the account's balances, converted to USD, and
+// records the result against the persisted equity curve.
+func (s *Orderer) updateEquity(ctx context.Context) {
+	equity, err := s.accountEquityUSD(ctx)
+	if err != nil {
+		s.logger.Error("[UpdateEquity] failed to get account equity", zap.Error(err))
+		return
+	}
+
+	s.state.UpdateEquity(equity, s.settings.MaxDrawdownPercent)
+}
+
+// accountEquityUSD sums the account's wallet balances, converting any
+// non-stable asset (e.g. BNB, BTC collateral) to USD via its current
+// price against USDT.
+func (s *Orderer) accountEquityUSD(ctx context.Context) (float64, error) {
+	balances, err := s.binance.GetBalances(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var equity float64
+
+	for _, balance := range balances {
+		amount := helpers.StringToFloat(balance.CrossWalletBalance) + helpers.StringToFloat(balance.CrossUnPnl)
+		if amount == 0 {
+			continue
+		}
+
+		if stableAssets[balance.Asset] {
+			equity += amount
+			continue
+		}
+
+		price, err := s.binance.GetCurrentPrice(ctx, balance.Asset+"USDT")
+		if err != nil {
+			s.logger.Error("[UpdateEquity] failed to price collateral asset", zap.String("asset", balance.Asset), zap.Error(err))
+			continue
+		}
+
+		equity += amount * helpers.StringToFloat(price.Price)
+	}
+
+	return equity, nil
+}
+
+// deleverageMultiplier is the position-size multiplier
+// risk.EquityCurve.SizeMultiplier has decided for the account's
+// current drawdown, or 1 (no deleveraging) if equity tracking hasn't
+// recorded an observation yet.
+func (s *Orderer) deleverageMultiplier() float64 {
+	if s.state == nil {
+		return 1
+	}
+
+	curve := s.state.Equity()
+	if curve == nil {
+		return 1
+	}
+
+	return curve.SizeMultiplier(s.settings.MaxDrawdownPercent)
+}
@@ -0,0 +1,330 @@
+package orderer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/models"
+	binancew "github.com/anvh2/futures-trading/internal/services/binance"
+	"github.com/anvh2/futures-trading/internal/state"
+	"github.com/anvh2/futures-trading/internal/talib"
+	"go.uber.org/zap"
+)
+
+// oscillatorCachePrefix namespaces cached *models.Oscillator entries
+// within s.cache so they can't collide with the []*models.Order entries
+// open() caches per symbol for the same key.
+const oscillatorCachePrefix = "oscillator:"
+
+func oscillatorCacheKey(symbol string) string {
+	return oscillatorCachePrefix + symbol
+}
+
+// startExitEvaluation periodically re-scores every held position
+// against the latest cached oscillator for its symbol, so a position
+// can be closed the moment its entry bias flips or its indicators
+// collapse out of trading range, instead of only ever closing via its
+// take-profit/stop order. A non-positive ExitEvaluationIntervalSeconds
+// disables it.
+func (s *Orderer) startExitEvaluation() {
+	interval := time.Duration(s.settings.ExitEvaluationIntervalSeconds) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.evaluateExits(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// evaluateExits re-scores every symbol with a held position against the
+// latest oscillator cached for it, closing the position if the resolved
+// bias has flipped against the held side, the indicator has drifted
+// out of RangeBoundReadyTrade (confidence collapse), or it's been held
+// longer than settings.MaxHoldingPeriodFor (a ranging trade that sat
+// without either indicator signal ever firing, eating funding). A
+// symbol with no cached oscillator yet only has the holding-period
+// check applied.
+func (s *Orderer) evaluateExits(ctx context.Context) {
+	for symbol, position := range s.state.GetState().Positions {
+		if reason, expired := holdingPeriodExpired(position, s.settings.MaxHoldingPeriodFor(s.settings.TradingStrategy)); expired {
+			if err := s.closePosition(ctx, position, reason); err != nil {
+				s.logger.Error("[EvaluateExits] failed to close position", zap.String("symbol", symbol), zap.String("reason", reason), zap.Error(err))
+			}
+			continue
+		}
+
+		cached := s.cache.Get(oscillatorCacheKey(symbol))
+		if cached == nil {
+			continue
+		}
+
+		oscillator, ok := cached.(*models.Oscillator)
+		if !ok {
+			continue
+		}
+
+		stoch := oscillator.Stoch[s.settings.IntervalFor(s.settings.TradingStrategy)]
+
+		reason, shouldClose := exitReason(position.Side, stoch)
+		if !shouldClose {
+			s.applyBreakEvenStop(ctx, position)
+			continue
+		}
+
+		if err := s.closePosition(ctx, position, reason); err != nil {
+			s.logger.Error("[EvaluateExits] failed to close position", zap.String("symbol", symbol), zap.String("reason", reason), zap.Error(err))
+		}
+	}
+}
+
+// applyBreakEvenStop moves position's stop-loss to its entry price
+// (plus the symbol's maker and taker rate from commissionRate, which
+// fall back to settings.BreakEvenFeeBufferFraction in total until actual
+// rates have been fetched) once unrealized profit
+// reaches settings.BreakEvenRMultiple times its entry-to-stop distance
+// ("R"), the "trail to BE" step of a scale-out plan. A no-op if the
+// position has no stop-loss order, has already had its stop moved, or
+// BreakEvenRMultiple is disabled.
+func (s *Orderer) applyBreakEvenStop(ctx context.Context, position *state.PositionRecord) {
+	if position.BreakEvenApplied || position.StopOrderId == "" || s.settings.BreakEvenRMultiple <= 0 {
+		return
+	}
+
+	entry := helpers.StringToFloat(position.EntryPrice)
+	stop := helpers.StringToFloat(position.StopPrice)
+	if entry <= 0 || stop <= 0 {
+		return
+	}
+
+	ticker, err := s.binance.GetCurrentPrice(ctx, position.Symbol)
+	if err != nil {
+		s.logger.Error("[EvaluateExits] failed to get current price", zap.String("symbol", position.Symbol), zap.Error(err))
+		return
+	}
+	current := helpers.StringToFloat(ticker.Price)
+
+	maker, taker := s.commissionRate(position.Symbol)
+	feeBuffer := maker + taker
+
+	r := entry - stop
+	breakEvenPrice := entry * (1 + feeBuffer)
+	if position.Side == string(futures.PositionSideTypeShort) {
+		r = stop - entry
+		breakEvenPrice = entry * (1 - feeBuffer)
+	}
+	if r <= 0 {
+		return
+	}
+
+	unrealized := current - entry
+	if position.Side == string(futures.PositionSideTypeShort) {
+		unrealized = entry - current
+	}
+
+	if unrealized < r*s.settings.BreakEvenRMultiple {
+		return
+	}
+
+	orderId, err := strconv.ParseInt(position.StopOrderId, 10, 64)
+	if err != nil {
+		s.logger.Error("[EvaluateExits] invalid stop order id", zap.String("symbol", position.Symbol), zap.String("stopOrderId", position.StopOrderId), zap.Error(err))
+		return
+	}
+
+	if _, err := s.binance.CancelOrder(ctx, position.Symbol, orderId); err != nil {
+		s.logger.Error("[EvaluateExits] failed to cancel stop order", zap.String("symbol", position.Symbol), zap.Int64("orderId", orderId), zap.Error(err))
+		return
+	}
+
+	closeSide := futures.SideTypeSell
+	if position.Side == string(futures.PositionSideTypeShort) {
+		closeSide = futures.SideTypeBuy
+	}
+
+	newStop := &models.Order{
+		Symbol:           position.Symbol,
+		Side:             closeSide,
+		PositionSide:     futures.PositionSideType(position.Side),
+		OrderType:        futures.OrderTypeStopMarket,
+		StopPrice:        helpers.FloatToString(breakEvenPrice),
+		ClosePosition:    true,
+		WorkingType:      futures.WorkingTypeMarkPrice,
+		NewOrderRespType: futures.NewOrderRespTypeRESULT,
+	}
+
+	resp, err := s.binance.OpenOrders(ctx, []*models.Order{newStop})
+	if err != nil {
+		s.logger.Error("[EvaluateExits] failed to place break-even stop", zap.String("symbol", position.Symbol), zap.Error(err))
+		return
+	}
+
+	s.state.MoveStop(position.Symbol, newStop.StopPrice, strconv.Itoa(resp[0].OrderId))
+
+	s.logger.Info("[EvaluateExits] moved stop to break-even", zap.String("symbol", position.Symbol), zap.Float64("breakEvenPrice", breakEvenPrice))
+}
+
+// holdingPeriodExpired reports whether position has been held longer
+// than maxMinutes. maxMinutes <= 0 disables the check.
+func holdingPeriodExpired(position *state.PositionRecord, maxMinutes int) (string, bool) {
+	if maxMinutes <= 0 || position.OpenedAt().IsZero() {
+		return "", false
+	}
+
+	if time.Since(position.OpenedAt()) >= time.Duration(maxMinutes)*time.Minute {
+		return "max_holding_period_exceeded", true
+	}
+
+	return "", false
+}
+
+// exitReason reports whether the position held on side should be closed
+// given stoch, and why: "bias_flip" when stoch now resolves to the
+// opposite side, "confidence_collapse" when stoch has drifted out of
+// RangeBoundReadyTrade entirely.
+func exitReason(side string, stoch *models.Stoch) (string, bool) {
+	if !talib.WithinRangeBound(stoch, talib.RangeBoundReadyTrade) {
+		return "confidence_collapse", true
+	}
+
+	resolved, err := talib.ResolvePositionSide(stoch, talib.RangeBoundReadyTrade)
+	if err != nil {
+		return "confidence_collapse", true
+	}
+
+	if string(resolved) != side {
+		return "bias_flip", true
+	}
+
+	return "", false
+}
+
+// closePosition submits a reduce-only market order that flattens
+// position's entire filled quantity, records the close against state,
+// and stops tracking the position.
+func (s *Orderer) closePosition(ctx context.Context, position *state.PositionRecord, reason string) error {
+	closeSide := futures.SideTypeSell
+	if position.Side == string(futures.PositionSideTypeShort) {
+		closeSide = futures.SideTypeBuy
+	}
+
+	order := &models.Order{
+		Symbol:           position.Symbol,
+		Side:             closeSide,
+		PositionSide:     futures.PositionSideType(position.Side),
+		OrderType:        futures.OrderTypeMarket,
+		ClosePosition:    true,
+		WorkingType:      futures.WorkingTypeMarkPrice,
+		NewOrderRespType: futures.NewOrderRespTypeRESULT,
+	}
+
+	resp, err := s.binance.OpenOrders(ctx, []*models.Order{order})
+	if err != nil {
+		return err
+	}
+
+	for _, order := range resp {
+		s.state.RecordOrderEvent(position.Symbol, position.Side, position.Profile, &state.OrderEvent{
+			OrderId:   strconv.Itoa(order.OrderId),
+			Type:      state.OrderEventFilled,
+			Price:     order.Price,
+			Quantity:  order.OrigQty,
+			Timestamp: time.Now().UnixMilli(),
+		})
+	}
+
+	s.state.ClosePosition(position.Symbol)
+
+	if len(resp) > 0 {
+		result := s.recordTradeResult(position, resp[0])
+		s.notifyTradeClose(ctx, position, resp[0], reason, result)
+	}
+
+	s.logger.Info("[EvaluateExits] closed position", zap.String("symbol", position.Symbol), zap.String("side", position.Side), zap.String("reason", reason), zap.Any("resp", resp))
+	return nil
+}
+
+// tradingHistory returns the rolling TradeResult history tracked for
+// symbol under the current trading strategy, creating it if this is the
+// first trade recorded for this key. Keyed identically to
+// analyzer.Analyzer.tradingHistory, which reads what this writes.
+func (s *Orderer) tradingHistory(symbol string) *models.TradingHistory {
+	key := fmt.Sprintf("history.%s.%d", symbol, s.settings.TradingStrategy)
+
+	history, _ := s.cache.Get(key).(*models.TradingHistory)
+	if history == nil {
+		history = models.NewTradingHistory(0)
+		s.cache.Set(key, history)
+	}
+
+	return history
+}
+
+// recordTradeResult appends a TradeResult for position's just-closed
+// trade to its symbol's TradingHistory, expressing realized PNL as an
+// R-multiple of its initial entry-to-stop risk (RiskAmount), so
+// performance can be evaluated in R terms rather than raw dollars. A
+// position with no stop-loss order has no RiskAmount to express PNL
+// against and is recorded with it left at zero, excluding it from AvgR.
+//
+// PNL is net of the round-trip commission actually charged: the entry
+// leg at its LIMIT maker rate, the exit leg at close's MARKET/
+// TAKE_PROFIT_MARKET/STOP_MARKET taker rate, per Orderer.commissionRate.
+func (s *Orderer) recordTradeResult(position *state.PositionRecord, close *binancew.CreateOrderResp) *models.TradeResult {
+	entry := helpers.StringToFloat(position.EntryPrice)
+	exit := helpers.StringToFloat(close.AvgPrice)
+	quantity := helpers.StringToFloat(close.ExecutedQty)
+
+	maker, taker := s.commissionRate(position.Symbol)
+	fee := quantity*entry*maker + quantity*exit*taker
+
+	pnl := (exit-entry)*quantity - fee
+	if position.Side == string(futures.PositionSideTypeShort) {
+		pnl = (entry-exit)*quantity - fee
+	}
+
+	var riskAmount float64
+	if stop := helpers.StringToFloat(position.StopPrice); stop > 0 && entry > 0 {
+		r := entry - stop
+		if position.Side == string(futures.PositionSideTypeShort) {
+			r = stop - entry
+		}
+		if r > 0 {
+			riskAmount = r * quantity
+		}
+	}
+
+	result := &models.TradeResult{
+		Symbol:     position.Symbol,
+		Side:       position.Side,
+		Win:        pnl > 0,
+		PNL:        pnl,
+		OpenedAt:   position.OpenedAt().UnixMilli(),
+		ClosedAt:   time.Now().UnixMilli(),
+		RiskAmount: riskAmount,
+		FeePaid:    fee,
+	}
+
+	if err := s.tradingHistory(position.Symbol).Add(result); err != nil {
+		s.logger.Error("[EvaluateExits] failed to archive trade result", zap.String("symbol", position.Symbol), zap.Error(err))
+	}
+
+	s.shadow.RecordReal(result)
+
+	return result
+}
@@ -0,0 +1,94 @@
+package orderer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/cache/basic"
+	"github.com/anvh2/futures-trading/internal/cache/exchange"
+	"github.com/anvh2/futures-trading/internal/cache/market"
+	"github.com/anvh2/futures-trading/internal/config"
+	"github.com/anvh2/futures-trading/internal/libs/queue"
+	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/services/binance/simulated"
+	telemock "github.com/anvh2/futures-trading/internal/services/telegram/mocks"
+	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/anvh2/futures-trading/internal/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationOpenAgainstSimulatedExchange boots a real Orderer,
+// wired to services/binance/simulated instead of live Binance and fed
+// through the real queue.Queue, the way Start's consumer loop would.
+// It replaces asserting against exchange call counts with asserting
+// the resulting position lands in the real StateManager, since that's
+// what downstream consumers (safety rules, /safety_incidents, reports)
+// actually read.
+func TestIntegrationOpenAgainstSimulatedExchange(t *testing.T) {
+	symbol := "BTCUSDT"
+	interval := "15m"
+
+	cfg := settings.NewDefaultSettings()
+	cfg.TradingEnabled = true
+
+	mkt := market.NewMarket(10)
+	for _, candle := range []*models.Candlestick{
+		{High: "27500", Low: "27000", Close: "27250"},
+		{High: "27600", Low: "27100", Close: "27300"},
+	} {
+		require.NoError(t, mkt.CreateSummary(symbol).CreateCandle(interval, candle))
+	}
+
+	exchangeCache := exchange.New(_loggerTest)
+	exchangeCache.Set([]*exchange.Symbol{
+		{
+			Symbol: symbol,
+			Filters: &exchange.Filters{
+				{FilterType: futures.SymbolFilterTypePrice, MinPrice: "0.10", MaxPrice: "1000000", TickSize: "0.10"},
+				{FilterType: futures.SymbolFilterTypeLotSize, StepSize: "0.001"},
+				{FilterType: futures.SymbolFilterTypeMarketLotSize, StepSize: "0.001"},
+				{FilterType: futures.SymbolFilterTypeMinNotional, Notional: "5.0"},
+			},
+		},
+	})
+
+	exchange := simulated.New(_loggerTest, mkt, cfg)
+
+	q := queue.New()
+	defer q.Close()
+
+	tradingState := state.New(_loggerTest, t.TempDir()+"/state.json")
+
+	order := New(_loggerTest, config.BinanceConfig{RateLimitRequests: 200, RateLimitDuration: time.Minute}, &telemock.NotifyMock{
+		PushNotifyFunc: func(ctx context.Context, chatId int64, message string) error { return nil },
+	}, mkt, exchangeCache, q, cfg, tradingState, basic.NewCache()).WithClient(exchange)
+
+	if err := order.worker.Start(); err != nil {
+		t.Fatalf("failed to start worker: %v", err)
+	}
+	defer order.worker.Stop()
+
+	oscillator := &models.Oscillator{
+		Symbol: symbol,
+		Stoch: map[string]*models.Stoch{
+			interval: {RSI: 15, K: 12, D: 14},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, q.Push(oscillator, time.Minute))
+
+	msg, err := q.Consume(ctx, "orderer")
+	require.NoError(t, err)
+	require.NoError(t, order.open(context.Background(), msg.Data))
+
+	position, ok := tradingState.Position(symbol)
+	require.True(t, ok, "expected a position to be recorded in StateManager")
+	assert.Equal(t, symbol, position.Symbol)
+	assert.NotEmpty(t, position.Events)
+}
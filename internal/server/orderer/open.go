@@ -5,12 +5,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/adshao/go-binance/v2/futures"
 	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/metrics"
 	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/risk"
 	binancew "github.com/anvh2/futures-trading/internal/services/binance"
+	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/anvh2/futures-trading/internal/state"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
@@ -38,10 +45,22 @@ func (s *Orderer) open(ctx context.Context, data interface{}) error {
 		return err
 	}
 
-	if s.cache.Exs(oscillator.Symbol) {
+	// Cache the latest oscillator per symbol regardless of whether it
+	// ends up producing an entry decision below, so evaluateExits can
+	// re-score a held position against it later.
+	s.cache.Set(oscillatorCacheKey(oscillator.Symbol), oscillator)
+
+	if s.settings.IsBlacklisted(oscillator.Symbol) {
+		metrics.RejectedDecisions.WithLabelValues("blacklisted").Inc()
+		return errors.New("trading: symbol is blacklisted")
+	}
+
+	if !s.leases.Acquire(oscillator.Symbol) {
+		metrics.RejectedDecisions.WithLabelValues("symbol_processing").Inc()
 		s.logger.Info("[OpenOrders] symbol is processing", zap.String("symbol", oscillator.Symbol))
 		return nil
 	}
+	defer s.leases.Release(oscillator.Symbol)
 
 	openPositions, err := s.binance.GetPositionRisk(ctx, "")
 	if err != nil {
@@ -49,8 +68,11 @@ func (s *Orderer) open(ctx context.Context, data interface{}) error {
 		return err
 	}
 
-	if positionExisted(openPositions, oscillator.Symbol) {
-		s.logger.Info("[OpenOrders] position existed", zap.String("symbol", oscillator.Symbol), zap.Any("openPositions", openPositions))
+	desiredSide := helpers.ResolvePositionSide(oscillator.GetRSI(s.settings.IntervalFor(s.settings.TradingStrategy)))
+
+	if positionExisted(openPositions, oscillator.Symbol, desiredSide) {
+		metrics.RejectedDecisions.WithLabelValues("position_exists").Inc()
+		s.logger.Info("[OpenOrders] position existed", zap.String("symbol", oscillator.Symbol), zap.String("side", desiredSide), zap.Any("openPositions", openPositions))
 		return nil
 	}
 
@@ -61,24 +83,62 @@ func (s *Orderer) open(ctx context.Context, data interface{}) error {
 	}
 
 	if orderExisted(openOrders, oscillator.Symbol) {
+		metrics.RejectedDecisions.WithLabelValues("order_exists").Inc()
 		s.logger.Info("[OpenOrders] order existed", zap.String("symbol", oscillator.Symbol), zap.Any("orders", openOrders))
 		return nil
 	}
 
 	if err := s.checkOrderAndPositionQuantity(openOrders, openPositions); err != nil {
+		metrics.RejectedDecisions.WithLabelValues("max_positions_reached").Inc()
 		s.logger.Info("[OpenOrders] check quantity error", zap.String("symbol", oscillator.Symbol), zap.Error(err))
 		return nil
 	}
 
+	if err := s.checkGlobalExposure(ctx, openPositions); err != nil {
+		metrics.RejectedDecisions.WithLabelValues("max_exposure_reached").Inc()
+		s.logger.Info("[OpenOrders] check exposure error", zap.String("symbol", oscillator.Symbol), zap.Error(err))
+		return nil
+	}
+
+	if !s.throttle.Allow(oscillator.Symbol) {
+		metrics.RejectedDecisions.WithLabelValues("throttled").Inc()
+		s.logger.Info("[OpenOrders] throttled, too many positions opened recently", zap.String("symbol", oscillator.Symbol))
+		return nil
+	}
+
+	if blocked, err := s.inFundingAvoidanceWindow(ctx, oscillator); err != nil {
+		s.logger.Error("[OpenOrders] failed to check funding window", zap.String("symbol", oscillator.Symbol), zap.Error(err))
+	} else if blocked {
+		metrics.RejectedDecisions.WithLabelValues("funding_window").Inc()
+		s.logger.Info("[OpenOrders] within funding avoidance window", zap.String("symbol", oscillator.Symbol))
+		return nil
+	}
+
 	s.logger.Info("orders and positions", zap.Any("positions", openPositions), zap.Any("orders", openOrders))
 
-	orders, err := s.create(ctx, oscillator.Symbol, oscillator.Stoch[s.settings.TradingInterval])
+	orders, err := s.create(ctx, oscillator.Symbol, oscillator.Stoch[s.settings.IntervalFor(s.settings.TradingStrategy)], oscillator.ATR, oscillator.Confidence)
 	if err != nil {
-		s.logger.Info("[OpenOrders] failed to make orders", zap.Any("stoch", oscillator.Stoch[s.settings.TradingInterval]), zap.Error(err))
+		s.logger.Info("[OpenOrders] failed to make orders", zap.Any("stoch", oscillator.Stoch[s.settings.IntervalFor(s.settings.TradingStrategy)]), zap.Error(err))
 		return err
 	}
 
-	s.logger.Info("[OpenOrders] make orders success", zap.String("symbol", oscillator.Symbol), zap.Any("stoch", oscillator.Stoch[s.settings.TradingInterval]), zap.Any("orders", orders))
+	s.logger.Info("[OpenOrders] make orders success", zap.String("symbol", oscillator.Symbol), zap.Any("stoch", oscillator.Stoch[s.settings.IntervalFor(s.settings.TradingStrategy)]), zap.Any("orders", orders))
+
+	if passes, err := s.passesLiquidityFilter(ctx, oscillator.Symbol, orders[0]); err != nil {
+		s.logger.Error("[OpenOrders] failed to check liquidity", zap.String("symbol", oscillator.Symbol), zap.Error(err))
+	} else if !passes {
+		metrics.RejectedDecisions.WithLabelValues("low_liquidity").Inc()
+		s.shadow.Record(oscillator.Symbol, "low_liquidity", helpers.StringToFloat(orders[0].Price))
+		s.logger.Info("[OpenOrders] rejected, spread/book too thin", zap.String("symbol", oscillator.Symbol))
+		return nil
+	}
+
+	if wouldSelfTrade(openOrders, oscillator.Symbol, orders[0].Side, helpers.StringToFloat(orders[0].Price)) {
+		metrics.RejectedDecisions.WithLabelValues("self_trade").Inc()
+		s.shadow.Record(oscillator.Symbol, "self_trade", helpers.StringToFloat(orders[0].Price))
+		s.logger.Info("[OpenOrders] rejected, would cross a resting order from this system", zap.String("symbol", oscillator.Symbol))
+		return nil
+	}
 
 	resp, err := s.binance.OpenOrders(ctx, orders)
 	if err != nil {
@@ -86,9 +146,66 @@ func (s *Orderer) open(ctx context.Context, data interface{}) error {
 		return err
 	}
 
+	// Only commit to the throttle and cluster windows once the position
+	// has actually cleared every rejection point above -- Allow and
+	// SizeMultiple are previews, not records, so an attempt rejected by
+	// liquidity, self-trade, or the exchange itself never burns a slot
+	// it didn't use.
+	s.throttle.Commit(oscillator.Symbol)
+	s.cluster.Commit(oscillator.Symbol)
+
+	if s.settings.TradingStrategy == settings.TradingStrategyFundingWindowScalp {
+		s.fundingScalpThrottle.Commit(oscillator.Symbol)
+	}
+
 	s.cache.Set(oscillator.Symbol, orders)
 
-	notifyMsg := fmt.Sprintf("Open orders success: %s #%s", helpers.ResolvePositionSide(oscillator.GetRSI(s.settings.TradingInterval)), oscillator.Symbol)
+	var filledQty float64
+
+	if len(resp) > 0 {
+		s.recordSlippage(oscillator.Symbol, string(orders[0].PositionSide), orders[0], resp[0])
+
+		entryQty := helpers.StringToFloat(orders[0].Quantity)
+		filledQty = helpers.StringToFloat(resp[0].ExecutedQty)
+
+		if filledQty > 0 && filledQty < entryQty {
+			s.logger.Info("[OpenOrders] entry partially filled", zap.String("symbol", oscillator.Symbol), zap.Float64("requested", entryQty), zap.Float64("filled", filledQty))
+
+			if exchange, err := s.exchangeCache.Get(oscillator.Symbol); err != nil {
+				s.logger.Error("[OpenOrders] failed to get exchange info for partial fill", zap.String("symbol", oscillator.Symbol), zap.Error(err))
+			} else if lotFilter, err := exchange.GetLotSizeFilter(); err != nil {
+				s.logger.Error("[OpenOrders] failed to get lot filter for partial fill", zap.String("symbol", oscillator.Symbol), zap.Error(err))
+			} else {
+				orders, resp = s.resizeExitOrders(ctx, oscillator.Symbol, orders, resp, filledQty, lotFilter.StepSize)
+			}
+
+			s.watchPartialFill(oscillator.Symbol, int64(resp[0].OrderId), entryQty, filledQty)
+		}
+	}
+
+	if s.state != nil {
+		side := string(orders[0].PositionSide)
+		for _, order := range resp {
+			s.state.RecordOrderEvent(oscillator.Symbol, side, s.settings.ActiveProfile(), &state.OrderEvent{
+				OrderId:   strconv.Itoa(order.OrderId),
+				Type:      state.OrderEventCreated,
+				Price:     order.Price,
+				Quantity:  order.OrigQty,
+				Timestamp: time.Now().UnixMilli(),
+			})
+		}
+
+		if stopIdx := stopOrderIndex(orders); stopIdx >= 0 && stopIdx < len(resp) {
+			takeProfitPrice := ""
+			if tpIdx := takeProfitOrderIndex(orders); tpIdx >= 0 {
+				takeProfitPrice = orders[tpIdx].StopPrice
+			}
+
+			s.state.SetLevels(oscillator.Symbol, orders[0].Price, orders[stopIdx].StopPrice, strconv.Itoa(resp[stopIdx].OrderId), takeProfitPrice, filledQty)
+		}
+	}
+
+	notifyMsg := fmt.Sprintf("Open orders success: %s #%s", helpers.ResolvePositionSide(oscillator.GetRSI(s.settings.IntervalFor(s.settings.TradingStrategy))), oscillator.Symbol)
 	err = s.notify.PushNotify(ctx, viper.GetInt64("notify.channels.futures_announcement"), notifyMsg)
 	if err != nil {
 		s.logger.Error("[OpenOrders] failed to push notification", zap.Error(err))
@@ -99,6 +216,53 @@ func (s *Orderer) open(ctx context.Context, data interface{}) error {
 	return nil
 }
 
+// inFundingAvoidanceWindow reports whether opening oscillator's resolved
+// position side right now would pay away the next funding settlement,
+// per settings.FundingAvoidanceMinutes.
+func (s *Orderer) inFundingAvoidanceWindow(ctx context.Context, oscillator *models.Oscillator) (bool, error) {
+	if s.settings.FundingAvoidanceMinutes <= 0 {
+		return false, nil
+	}
+
+	premium, err := s.binance.GetPremiumIndex(ctx, oscillator.Symbol)
+	if err != nil {
+		return false, err
+	}
+
+	cfg := risk.FundingWindowConfig{AvoidBefore: time.Duration(s.settings.FundingAvoidanceMinutes) * time.Minute}
+	positionSide := helpers.ResolvePositionSide(oscillator.GetRSI(s.settings.IntervalFor(s.settings.TradingStrategy)))
+	fundingRate := helpers.StringToFloat(premium.LastFundingRate)
+	nextFundingTime := time.UnixMilli(premium.NextFundingTime)
+
+	return risk.InFundingAvoidanceWindow(cfg, positionSide, fundingRate, nextFundingTime, time.Now()), nil
+}
+
+// passesLiquidityFilter reports whether the book ticker for symbol is
+// tight and deep enough to take entry cleanly, per settings.MaxSpreadBps
+// and settings.MinBookSizeRatio.
+func (s *Orderer) passesLiquidityFilter(ctx context.Context, symbol string, entry *models.Order) (bool, error) {
+	if s.settings.MaxSpreadBps <= 0 && s.settings.MinBookSizeRatio <= 0 {
+		return true, nil
+	}
+
+	ticker, err := s.binance.GetBookTicker(ctx, symbol)
+	if err != nil {
+		return false, err
+	}
+
+	topOfBookSize := helpers.StringToFloat(ticker.AskQuantity)
+	if entry.Side == futures.SideTypeSell {
+		topOfBookSize = helpers.StringToFloat(ticker.BidQuantity)
+	}
+
+	cfg := risk.LiquidityConfig{MaxSpreadBps: s.settings.MaxSpreadBps, MinBookSizeRatio: s.settings.MinBookSizeRatio}
+	bidPrice := helpers.StringToFloat(ticker.BidPrice)
+	askPrice := helpers.StringToFloat(ticker.AskPrice)
+	quantity := helpers.StringToFloat(entry.Quantity)
+
+	return risk.PassesLiquidityFilter(cfg, bidPrice, askPrice, topOfBookSize, quantity), nil
+}
+
 func (s *Orderer) checkOrderAndPositionQuantity(orders []*binancew.Order, positions []*binancew.Position) error {
 	counter := 0
 
@@ -127,15 +291,92 @@ func (s *Orderer) checkOrderAndPositionQuantity(orders []*binancew.Order, positi
 	return nil
 }
 
-func positionExisted(positions []*binancew.Position, symbol string) bool {
+// checkGlobalExposure rejects a new position if the account's total open
+// notional, including positions opened manually outside this system,
+// already sits at or beyond settings.MaxTotalExposureRatio times its
+// actual equity (from Client.GetBalances, see accountEquityUSD). Unlike
+// checkOrderAndPositionQuantity, which only counts how many positions
+// are open, this looks at how much capital they commit, computed from
+// GetPositionRisk's reported Notional rather than assumed against a
+// fixed balance. A non-positive MaxTotalExposureRatio disables the
+// check.
+func (s *Orderer) checkGlobalExposure(ctx context.Context, positions []*binancew.Position) error {
+	if s.settings.MaxTotalExposureRatio <= 0 {
+		return nil
+	}
+
+	equity, err := s.accountEquityUSD(ctx)
+	if err != nil {
+		return err
+	}
+
+	if equity <= 0 {
+		return nil
+	}
+
+	var notional float64
+	for _, pos := range positions {
+		if isPosititionOpened(pos) {
+			notional += math.Abs(helpers.StringToFloat(pos.Notional))
+		}
+	}
+
+	if notional >= equity*s.settings.MaxTotalExposureRatio {
+		return errors.New("trading: reached max global exposure")
+	}
+
+	return nil
+}
+
+// positionExisted reports whether a position on symbol and positionSide
+// is already open. In hedge mode a LONG and a SHORT on the same symbol
+// are tracked independently, so an opposing position must not block a
+// new entry on positionSide.
+func positionExisted(positions []*binancew.Position, symbol, positionSide string) bool {
 	for _, pos := range positions {
-		if pos.Symbol == symbol && isPosititionOpened(pos) {
+		if pos.Symbol == symbol && pos.PositionSide == positionSide && isPosititionOpened(pos) {
 			return true
 		}
 	}
 	return false
 }
 
+// wouldSelfTrade reports whether a new order on symbol, with side and
+// limitPrice, would immediately match one of this system's own resting
+// orders on the opposite side, which would wash-trade against itself
+// instead of the market if two strategies/positions on the same symbol
+// ever priced an entry and an exit to cross. A zero limitPrice (e.g. a
+// market order) can't be checked and is never considered crossing.
+func wouldSelfTrade(orders []*binancew.Order, symbol string, side futures.SideType, limitPrice float64) bool {
+	if limitPrice <= 0 {
+		return false
+	}
+
+	for _, order := range orders {
+		if order.Symbol != symbol || order.Side == side {
+			continue
+		}
+
+		restingPrice := helpers.StringToFloat(order.Price)
+		if restingPrice <= 0 {
+			continue
+		}
+
+		switch side {
+		case futures.SideTypeBuy:
+			if limitPrice >= restingPrice {
+				return true
+			}
+		case futures.SideTypeSell:
+			if limitPrice <= restingPrice {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 func orderExisted(orders []*binancew.Order, symbol string) bool {
 	for _, order := range orders {
 		if order.Symbol == symbol {
@@ -145,6 +386,28 @@ func orderExisted(orders []*binancew.Order, symbol string) bool {
 	return false
 }
 
+// stopOrderIndex returns the index of orders' stop-loss order, or -1 if
+// the strategy didn't place one.
+func stopOrderIndex(orders []*models.Order) int {
+	for i, order := range orders {
+		if order.OrderType == futures.OrderTypeStopMarket {
+			return i
+		}
+	}
+	return -1
+}
+
+// takeProfitOrderIndex returns the index of orders' take-profit order,
+// or -1 if the strategy didn't place one.
+func takeProfitOrderIndex(orders []*models.Order) int {
+	for i, order := range orders {
+		if order.OrderType == futures.OrderTypeTakeProfitMarket {
+			return i
+		}
+	}
+	return -1
+}
+
 func isPosititionOpened(position *binancew.Position) bool {
 	if position.EntryPrice != "" &&
 		position.EntryPrice != "0.0" {
@@ -7,19 +7,22 @@ import (
 	"time"
 
 	"github.com/anvh2/futures-trading/internal/cache"
-	"github.com/anvh2/futures-trading/internal/cache/basic"
+	"github.com/anvh2/futures-trading/internal/config"
+	"github.com/anvh2/futures-trading/internal/libs/lease"
 	"github.com/anvh2/futures-trading/internal/libs/queue"
 	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/risk"
 	"github.com/anvh2/futures-trading/internal/services/binance"
 	"github.com/anvh2/futures-trading/internal/services/telegram"
 	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/anvh2/futures-trading/internal/state"
 	"github.com/anvh2/futures-trading/internal/worker"
 	"go.uber.org/zap"
 )
 
 type Orderer struct {
 	logger        *logger.Logger
-	binance       *binance.Binance
+	binance       binance.Client
 	notify        telegram.Notify
 	queue         *queue.Queue
 	settings      *settings.Settings
@@ -27,16 +30,28 @@ type Orderer struct {
 	worker        *worker.Worker
 	marketCache   cache.Market
 	exchangeCache cache.Exchange
-	quitChannel   chan struct{}
+	leases        *lease.Manager
+	throttle      *risk.Throttle
+	// fundingScalpThrottle gates TradingStrategyFundingWindowScalp behind
+	// its own risk budget, independent of throttle's global limits, see
+	// settings.FundingScalpMaxPositionsHourly/Daily.
+	fundingScalpThrottle *risk.Throttle
+	cluster              *risk.ClusterTracker
+	shadow               *risk.ShadowTracker
+	state                *state.StateManager
+	quitChannel          chan struct{}
 }
 
 func New(
 	logger *logger.Logger,
+	binanceConfig config.BinanceConfig,
 	notify telegram.Notify,
 	marketCache cache.Market,
 	exchangeCache cache.Exchange,
 	queue *queue.Queue,
 	settings *settings.Settings,
+	state *state.StateManager,
+	cache cache.Basic,
 ) *Orderer {
 	worker, err := worker.New(logger, &worker.PoolConfig{NumProcess: 8})
 	if err != nil {
@@ -45,15 +60,32 @@ func New(
 
 	orderer := &Orderer{
 		logger:        logger,
-		binance:       binance.New(logger, true),
+		binance:       binance.New(logger, binanceConfig, true),
 		notify:        notify,
 		queue:         queue,
 		settings:      settings,
-		cache:         basic.NewCache(),
+		cache:         cache,
 		worker:        worker,
 		marketCache:   marketCache,
 		exchangeCache: exchangeCache,
-		quitChannel:   make(chan struct{}),
+		leases:        lease.New(30 * time.Second),
+		throttle: risk.NewThrottle(risk.ThrottleConfig{
+			MaxPerSymbolHourly: int(settings.MaxPositionsPerSymbolHourly),
+			MaxPerSymbolDaily:  int(settings.MaxPositionsPerSymbolDaily),
+			MaxGlobalHourly:    int(settings.MaxPositionsGlobalHourly),
+			MaxGlobalDaily:     int(settings.MaxPositionsGlobalDaily),
+		}),
+		fundingScalpThrottle: risk.NewThrottle(risk.ThrottleConfig{
+			MaxGlobalHourly: settings.FundingScalpMaxPositionsHourly,
+			MaxGlobalDaily:  settings.FundingScalpMaxPositionsDaily,
+		}),
+		cluster: risk.NewClusterTracker(risk.ClusterConfig{
+			Groups:        settings.CorrelatedSymbolGroups,
+			WindowMinutes: settings.ClusterWindowMinutes,
+		}),
+		shadow:      risk.NewShadowTracker(),
+		state:       state,
+		quitChannel: make(chan struct{}),
 	}
 
 	orderer.worker.WithProcess(orderer.open)
@@ -61,7 +93,37 @@ func New(
 	return orderer
 }
 
+// WithClient overrides the Binance client orderer.New wires up, e.g. to
+// swap in services/binance/simulated for paper trading or an
+// integration test against a real Orderer/Queue/StateManager.
+func (o *Orderer) WithClient(client binance.Client) *Orderer {
+	o.binance = client
+	return o
+}
+
+// checkPositionMode logs a warning if the account is not running in
+// hedge mode, since open() always places orders with an explicit
+// PositionSide, which Binance rejects outside hedge mode.
+func (o *Orderer) checkPositionMode(ctx context.Context) {
+	mode, err := o.binance.GetPositionMode(ctx)
+	if err != nil {
+		o.logger.Warn("[Start] failed to get position mode", zap.Error(err))
+		return
+	}
+
+	if !mode.DualSidePosition {
+		o.logger.Warn("[Start] account is running in one-way mode, but orders are placed with an explicit position side")
+	}
+}
+
 func (o *Orderer) Start() error {
+	o.checkPositionMode(context.Background())
+	o.startExitEvaluation()
+	o.startEquityTracking()
+	o.startCommissionTracking()
+	o.startRebalanceAdvisor()
+	o.startShadowReview()
+
 	go func() {
 		defer func() {
 			if r := recover(); r != nil {
@@ -69,21 +131,26 @@ func (o *Orderer) Start() error {
 			}
 		}()
 
-		ticker := time.NewTicker(5 * time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			<-o.quitChannel
+			cancel()
+		}()
 
 		for {
-			select {
-			case <-ticker.C:
-				msg, err := o.queue.Peak("orderer")
-				if err != nil {
-					continue
+			msg, err := o.queue.Consume(ctx, "orderer")
+			if err != nil {
+				if ctx.Err() != nil {
+					return
 				}
 
-				o.worker.SendJob(context.Background(), msg.Data)
-
-			case <-o.quitChannel:
-				return
+				continue
 			}
+
+			o.worker.SendJob(context.Background(), msg.Data)
+			o.queue.Commit("orderer", msg.Offset)
 		}
 	}()
 
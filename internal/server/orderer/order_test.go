@@ -3,7 +3,9 @@ package orderer
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/anvh2/futures-trading/internal/config"
 	"github.com/anvh2/futures-trading/internal/logger"
 	"github.com/anvh2/futures-trading/internal/services/binance"
 	"github.com/joho/godotenv"
@@ -18,6 +20,6 @@ func TestMain(m *testing.M) {
 	godotenv.Load("../../../.env")
 
 	_loggerTest = logger.NewDev()
-	_binanceTestnetInst = binance.New(_loggerTest, true)
+	_binanceTestnetInst = binance.New(_loggerTest, config.BinanceConfig{RateLimitRequests: 200, RateLimitDuration: time.Minute}, true)
 	os.Exit(m.Run())
 }
@@ -0,0 +1,103 @@
+package orderer
+
+import (
+	"context"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/models"
+	binancew "github.com/anvh2/futures-trading/internal/services/binance"
+	"go.uber.org/zap"
+)
+
+// remainderCancelTimeout is how long an entry order is left open after a
+// partial fill before the remaining quantity is canceled outright.
+const remainderCancelTimeout = 5 * time.Minute
+
+// recomputeExitQuantities scales orders' take-profit/stop-loss quantities
+// for entrySymbol to match filledQty, instead of assuming the entry's
+// whole requested quantity filled. It matches exit legs by order type
+// (the same way stopOrderIndex/takeProfitOrderIndex do) rather than
+// ReduceOnly/ClosePosition, since create never sets either flag.
+func recomputeExitQuantities(orders []*models.Order, entrySymbol string, filledQty float64, stepSize string) []*models.Order {
+	for _, order := range orders {
+		if order.Symbol != entrySymbol {
+			continue
+		}
+
+		if order.OrderType != futures.OrderTypeTakeProfitMarket && order.OrderType != futures.OrderTypeStopMarket {
+			continue
+		}
+
+		order.Quantity = helpers.AlignQuantityToString(filledQty, stepSize)
+	}
+
+	return orders
+}
+
+// resizeExitOrders cancels the take-profit/stop-loss orders open() just
+// placed alongside a partially-filled entry and resubmits them at
+// filledQty, so a resting exit order isn't sized for a position larger
+// than what actually opened. orders and resp must be the parallel
+// slices open() got back from OpenOrders; the returned slices carry the
+// same indexing with the resized legs swapped in. Exit legs that fail to
+// cancel or resubmit are left as they were and logged, not retried here.
+func (s *Orderer) resizeExitOrders(ctx context.Context, symbol string, orders []*models.Order, resp []*binancew.CreateOrderResp, filledQty float64, stepSize string) ([]*models.Order, []*binancew.CreateOrderResp) {
+	recomputeExitQuantities(orders, symbol, filledQty, stepSize)
+
+	for _, idx := range []int{stopOrderIndex(orders), takeProfitOrderIndex(orders)} {
+		if idx < 0 || idx >= len(resp) {
+			continue
+		}
+
+		if _, err := s.binance.CancelOrder(ctx, symbol, int64(resp[idx].OrderId)); err != nil {
+			s.logger.Error("[PartialFill] failed to cancel oversized exit order", zap.String("symbol", symbol), zap.Int("orderId", resp[idx].OrderId), zap.Error(err))
+			continue
+		}
+
+		rebuilt, err := s.binance.OpenOrders(ctx, []*models.Order{orders[idx]})
+		if err != nil || len(rebuilt) == 0 {
+			s.logger.Error("[PartialFill] failed to resubmit resized exit order", zap.String("symbol", symbol), zap.Error(err))
+			continue
+		}
+
+		resp[idx] = rebuilt[0]
+	}
+
+	return orders, resp
+}
+
+// watchPartialFill waits for remainderCancelTimeout and, if the entry
+// order still hasn't filled the rest of its quantity, cancels the
+// remainder so the position size matches what actually executed.
+func (s *Orderer) watchPartialFill(symbol string, orderId int64, origQty, filledQty float64) {
+	if filledQty >= origQty {
+		return
+	}
+
+	time.AfterFunc(remainderCancelTimeout, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		openOrders, err := s.binance.GetOpenOrders(ctx, symbol)
+		if err != nil {
+			s.logger.Error("[PartialFill] failed to get open orders", zap.String("symbol", symbol), zap.Error(err))
+			return
+		}
+
+		for _, order := range openOrders {
+			if order.OrderID != orderId {
+				continue
+			}
+
+			if _, err := s.binance.CancelOrder(ctx, symbol, orderId); err != nil {
+				s.logger.Error("[PartialFill] failed to cancel remainder", zap.String("symbol", symbol), zap.Int64("orderId", orderId), zap.Error(err))
+				return
+			}
+
+			s.logger.Info("[PartialFill] canceled unfilled remainder", zap.String("symbol", symbol), zap.Int64("orderId", orderId), zap.Float64("filledQty", filledQty))
+			return
+		}
+	})
+}
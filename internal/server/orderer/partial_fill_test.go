@@ -0,0 +1,32 @@
+package orderer
+
+import (
+	"testing"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+func TestRecomputeExitQuantities(t *testing.T) {
+	orders := []*models.Order{
+		{Symbol: "BTCUSDT", OrderType: futures.OrderTypeLimit, Quantity: "1.000"},
+		{Symbol: "BTCUSDT", OrderType: futures.OrderTypeTakeProfitMarket, Quantity: "1.000"},
+		{Symbol: "BTCUSDT", OrderType: futures.OrderTypeStopMarket, Quantity: "1.000"},
+		{Symbol: "ETHUSDT", OrderType: futures.OrderTypeTakeProfitMarket, Quantity: "1.000"},
+	}
+
+	recomputeExitQuantities(orders, "BTCUSDT", 0.4, "0.001")
+
+	if orders[0].Quantity != "1.000" {
+		t.Errorf("entry order quantity = %s, want unchanged", orders[0].Quantity)
+	}
+	if orders[1].Quantity != "0.4" {
+		t.Errorf("take-profit quantity = %s, want 0.4", orders[1].Quantity)
+	}
+	if orders[2].Quantity != "0.4" {
+		t.Errorf("stop-loss quantity = %s, want 0.4", orders[2].Quantity)
+	}
+	if orders[3].Quantity != "1.000" {
+		t.Errorf("other symbol's order quantity = %s, want unchanged", orders[3].Quantity)
+	}
+}
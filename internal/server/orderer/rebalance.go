@@ -0,0 +1,209 @@
+package orderer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/state"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// startRebalanceAdvisor periodically reviews every held position
+// against its entry-to-stop risk budget ("R") and flags candidates for
+// rebalancing: a winner that's run up far beyond its planned R has
+// outgrown the risk budget it was opened against, and a position that's
+// sat for a long time near breakeven is stagnant, tying up a
+// risk-budget slot for no return. A non-positive
+// RebalanceCheckIntervalMinutes disables it.
+func (s *Orderer) startRebalanceAdvisor() {
+	interval := time.Duration(s.settings.RebalanceCheckIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.reviewRebalancing(context.Background())
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// reviewRebalancing scores every held position's current unrealized R
+// against RebalanceTrimRMultiple (an oversized winner) and, for
+// positions held at least RebalanceStagnantMinutes with |R| under
+// RebalanceStagnantRMultiple, flags it stagnant. Either suggestion is
+// only pushed as a notification unless RebalanceAutoExecute is set, in
+// which case it's also acted on, see suggestTrim/suggestStagnantClose.
+// A position missing an entry or stop price has no risk budget to
+// measure R against and is skipped.
+func (s *Orderer) reviewRebalancing(ctx context.Context) {
+	for _, position := range s.state.GetState().Positions {
+		r, ok := s.currentR(ctx, position)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case s.settings.RebalanceTrimRMultiple > 0 && r >= s.settings.RebalanceTrimRMultiple:
+			s.suggestTrim(ctx, position, r)
+
+		case s.settings.RebalanceStagnantMinutes > 0 &&
+			time.Since(position.OpenedAt()) >= time.Duration(s.settings.RebalanceStagnantMinutes)*time.Minute &&
+			math.Abs(r) <= s.settings.RebalanceStagnantRMultiple:
+			s.suggestStagnantClose(ctx, position, r)
+		}
+	}
+}
+
+// currentR returns position's current unrealized PNL expressed as a
+// multiple of its entry-to-stop risk, the same "R" applyBreakEvenStop
+// and recordTradeResult use. Returns false if position has no stop-loss
+// order recorded or the current price can't be fetched.
+func (s *Orderer) currentR(ctx context.Context, position *state.PositionRecord) (float64, bool) {
+	entry := helpers.StringToFloat(position.EntryPrice)
+	stop := helpers.StringToFloat(position.StopPrice)
+	if entry <= 0 || stop <= 0 {
+		return 0, false
+	}
+
+	ticker, err := s.binance.GetCurrentPrice(ctx, position.Symbol)
+	if err != nil {
+		s.logger.Error("[Rebalance] failed to get current price", zap.String("symbol", position.Symbol), zap.Error(err))
+		return 0, false
+	}
+	current := helpers.StringToFloat(ticker.Price)
+
+	risk := entry - stop
+	unrealized := current - entry
+	if position.Side == string(futures.PositionSideTypeShort) {
+		risk = stop - entry
+		unrealized = entry - current
+	}
+	if risk <= 0 {
+		return 0, false
+	}
+
+	return unrealized / risk, true
+}
+
+// suggestTrim notifies that position has grown to r times its planned
+// risk and is a candidate to trim. With RebalanceAutoExecute set, it
+// also trims the position by half via trimPosition.
+func (s *Orderer) suggestTrim(ctx context.Context, position *state.PositionRecord, r float64) {
+	msg := fmt.Sprintf("rebalance: %s #%s is up %.1fR, consider trimming the oversized winner", position.Side, position.Symbol, r)
+
+	if !s.settings.RebalanceAutoExecute {
+		s.notifySuggestion(ctx, msg)
+		return
+	}
+
+	if err := s.trimPosition(ctx, position, 0.5); err != nil {
+		if errors.Is(err, errNothingToTrim) {
+			s.notifySuggestion(ctx, msg+" -- could not trim automatically, no tracked filled quantity for this position, please trim manually")
+			return
+		}
+
+		s.logger.Error("[Rebalance] failed to trim oversized winner", zap.String("symbol", position.Symbol), zap.Error(err))
+		return
+	}
+
+	s.notifySuggestion(ctx, msg+" -- trimmed 50%")
+}
+
+// suggestStagnantClose notifies that position has sat near breakeven
+// for a while and is a candidate to close. With RebalanceAutoExecute
+// set, it also closes the position outright via closePosition.
+func (s *Orderer) suggestStagnantClose(ctx context.Context, position *state.PositionRecord, r float64) {
+	held := time.Since(position.OpenedAt()).Round(time.Minute)
+	msg := fmt.Sprintf("rebalance: %s #%s has sat at %.1fR for %s, consider closing the stagnant position", position.Side, position.Symbol, r, held)
+
+	if !s.settings.RebalanceAutoExecute {
+		s.notifySuggestion(ctx, msg)
+		return
+	}
+
+	if err := s.closePosition(ctx, position, "stagnant_position"); err != nil {
+		s.logger.Error("[Rebalance] failed to close stagnant position", zap.String("symbol", position.Symbol), zap.Error(err))
+		return
+	}
+
+	s.notifySuggestion(ctx, msg+" -- closed")
+}
+
+// errNothingToTrim is returned by trimPosition when position has no
+// tracked filled quantity to trim a fraction of, e.g. a position
+// adopted or opened before open() started recording FilledQty, or one
+// TrimPosition has already reduced to zero.
+var errNothingToTrim = errors.New("orderer: position has no tracked filled quantity to trim")
+
+// trimPosition submits a reduce-only market order for fraction of
+// position's tracked filled quantity and records the reduction against
+// state, the execution half of suggestTrim when RebalanceAutoExecute is
+// set.
+func (s *Orderer) trimPosition(ctx context.Context, position *state.PositionRecord, fraction float64) error {
+	quantity := position.FilledQty * fraction
+	if quantity <= 0 {
+		return errNothingToTrim
+	}
+
+	closeSide := futures.SideTypeSell
+	if position.Side == string(futures.PositionSideTypeShort) {
+		closeSide = futures.SideTypeBuy
+	}
+
+	order := &models.Order{
+		Symbol:           position.Symbol,
+		Side:             closeSide,
+		PositionSide:     futures.PositionSideType(position.Side),
+		OrderType:        futures.OrderTypeMarket,
+		Quantity:         helpers.FloatToString(quantity),
+		ReduceOnly:       true,
+		WorkingType:      futures.WorkingTypeMarkPrice,
+		NewOrderRespType: futures.NewOrderRespTypeRESULT,
+	}
+
+	resp, err := s.binance.OpenOrders(ctx, []*models.Order{order})
+	if err != nil {
+		return err
+	}
+
+	for _, order := range resp {
+		s.state.RecordOrderEvent(position.Symbol, position.Side, position.Profile, &state.OrderEvent{
+			OrderId:   strconv.Itoa(order.OrderId),
+			Type:      state.OrderEventFilled,
+			Price:     order.Price,
+			Quantity:  order.ExecutedQty,
+			Timestamp: time.Now().UnixMilli(),
+		})
+	}
+
+	s.state.TrimPosition(position.Symbol, quantity)
+
+	s.logger.Info("[Rebalance] trimmed oversized winner", zap.String("symbol", position.Symbol), zap.Float64("quantity", quantity))
+	return nil
+}
+
+// notifySuggestion pushes msg to the futures_announcement channel, the
+// same destination evaluateExits' trade-closed notifications use.
+func (s *Orderer) notifySuggestion(ctx context.Context, msg string) {
+	if err := s.notify.PushNotify(ctx, viper.GetInt64("notify.channels.futures_announcement"), msg); err != nil {
+		s.logger.Error("[Rebalance] failed to push notification", zap.Error(err))
+	}
+}
@@ -0,0 +1,77 @@
+package orderer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anvh2/futures-trading/internal/chart"
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/models"
+	binancew "github.com/anvh2/futures-trading/internal/services/binance"
+	"github.com/anvh2/futures-trading/internal/state"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// reviewChartCandles is how many of the trading interval's most recent
+// candles to include in the post-trade review chart, wide enough to
+// show the entry and exit without the chart getting too cluttered.
+const reviewChartCandles = 60
+
+// notifyTradeClose attaches a small candlestick chart of position's
+// entry/stop/take-profit levels to the trade-completed Telegram
+// notification, so a closed trade can be reviewed at a glance without
+// leaving the chat. A render or push failure only logs; it never
+// blocks the close itself since the position and trade result are
+// already recorded by the time this runs.
+func (s *Orderer) notifyTradeClose(ctx context.Context, position *state.PositionRecord, close *binancew.CreateOrderResp, reason string, result *models.TradeResult) {
+	levels := []chart.Level{
+		{Label: "entry", Price: helpers.StringToFloat(position.EntryPrice)},
+		{Label: "stop", Price: helpers.StringToFloat(position.StopPrice)},
+		{Label: "take_profit", Price: helpers.StringToFloat(position.TakeProfitPrice)},
+	}
+
+	photo, err := chart.Render(s.reviewCandles(position.Symbol), levels)
+	if err != nil {
+		s.logger.Error("[EvaluateExits] failed to render review chart", zap.String("symbol", position.Symbol), zap.Error(err))
+		return
+	}
+
+	caption := fmt.Sprintf(
+		"%s #%s closed (%s): entry %s -> exit %s, pnl %.4f",
+		position.Side, position.Symbol, reason, position.EntryPrice, close.AvgPrice, result.PNL,
+	)
+
+	if err := s.notify.PushPhoto(ctx, viper.GetInt64("notify.channels.futures_announcement"), photo, caption); err != nil {
+		s.logger.Error("[EvaluateExits] failed to push review chart", zap.String("symbol", position.Symbol), zap.Error(err))
+	}
+}
+
+// reviewCandles returns up to reviewChartCandles of the most recent
+// cached candles for symbol at the active trading interval, oldest
+// first, or nil if none are cached yet.
+func (s *Orderer) reviewCandles(symbol string) []*models.Candlestick {
+	summary, err := s.marketCache.CandleSummary(symbol)
+	if err != nil {
+		return nil
+	}
+
+	candles, err := summary.Candles(s.settings.IntervalFor(s.settings.TradingStrategy))
+	if err != nil {
+		return nil
+	}
+
+	sorted := candles.Sorted()
+	if len(sorted) > reviewChartCandles {
+		sorted = sorted[len(sorted)-reviewChartCandles:]
+	}
+
+	result := make([]*models.Candlestick, 0, len(sorted))
+	for _, item := range sorted {
+		if candle, ok := item.(*models.Candlestick); ok {
+			result = append(result, candle)
+		}
+	}
+
+	return result
+}
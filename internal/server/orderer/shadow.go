@@ -0,0 +1,67 @@
+package orderer
+
+import (
+	"context"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"go.uber.org/zap"
+)
+
+// startShadowReview periodically scores pending risk.ShadowTracker
+// decisions against how price actually moved since they were rejected
+// and logs the resulting risk.OpportunityCostReport, so a rejected
+// signal's cost can be weighed against what was actually traded. A
+// non-positive ShadowScoreIntervalMinutes disables it.
+func (s *Orderer) startShadowReview() {
+	interval := time.Duration(s.settings.ShadowScoreIntervalMinutes) * time.Minute
+	if interval <= 0 {
+		return
+	}
+
+	forwardWindow := time.Duration(s.settings.ShadowForwardWindowMinutes) * time.Minute
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.reviewShadowDecisions(context.Background(), forwardWindow)
+
+			case <-s.quitChannel:
+				return
+			}
+		}
+	}()
+}
+
+// reviewShadowDecisions scores every pending shadow decision at least
+// forwardWindow old against its symbol's current price, then logs the
+// updated opportunity-cost report comparing resolved shadow decisions
+// to real closed trades. A symbol whose current price can't be fetched
+// is left pending for the next review.
+func (s *Orderer) reviewShadowDecisions(ctx context.Context, forwardWindow time.Duration) {
+	s.shadow.Score(forwardWindow, func(symbol string) float64 {
+		ticker, err := s.binance.GetCurrentPrice(ctx, symbol)
+		if err != nil {
+			s.logger.Error("[Shadow] failed to get current price", zap.String("symbol", symbol), zap.Error(err))
+			return 0
+		}
+		return helpers.StringToFloat(ticker.Price)
+	})
+
+	report := s.shadow.Report()
+	if report.ShadowCount == 0 {
+		return
+	}
+
+	s.logger.Info("[Shadow] opportunity cost report",
+		zap.Int("shadowCount", report.ShadowCount),
+		zap.Float64("shadowAvgReturn", report.ShadowAvgReturn),
+		zap.Int("realCount", report.RealCount),
+		zap.Float64("realAvgReturn", report.RealAvgReturn),
+		zap.Float64("opportunityCost", report.OpportunityCost),
+	)
+}
@@ -0,0 +1,53 @@
+package orderer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/models"
+	binancew "github.com/anvh2/futures-trading/internal/services/binance"
+)
+
+// slippageHistory returns the rolling slippage history tracked for
+// symbol, creating it if this is the first fill recorded for this key.
+func (s *Orderer) slippageHistory(symbol string) *models.SlippageHistory {
+	key := fmt.Sprintf("slippage.%s", symbol)
+
+	history, _ := s.cache.Get(key).(*models.SlippageHistory)
+	if history == nil {
+		history = models.NewSlippageHistory(0)
+		s.cache.Set(key, history)
+	}
+
+	return history
+}
+
+// SlippageStats returns symbol's aggregated entry slippage stats, for
+// the dashboard API. Returns nil until at least one entry fill has been
+// recorded for this symbol.
+func (s *Orderer) SlippageStats(symbol string) *models.SlippageStats {
+	key := fmt.Sprintf("slippage.%s", symbol)
+
+	history, _ := s.cache.Get(key).(*models.SlippageHistory)
+	if history == nil {
+		return nil
+	}
+
+	return history.Stats(symbol)
+}
+
+// recordSlippage compares entry's decision-time price against order's
+// actual fill price and appends the result to symbol's SlippageHistory.
+// order with no fill yet (AvgPrice "0") is skipped, since it has
+// nothing to compare against.
+func (s *Orderer) recordSlippage(symbol, side string, entry *models.Order, order *binancew.CreateOrderResp) {
+	expected := helpers.StringToFloat(entry.Price)
+	actual := helpers.StringToFloat(order.AvgPrice)
+
+	if actual <= 0 {
+		return
+	}
+
+	s.slippageHistory(symbol).Add(models.NewSlippageRecord(symbol, side, expected, actual, time.Now()))
+}
@@ -0,0 +1,45 @@
+package orderer
+
+import (
+	"fmt"
+
+	"github.com/anvh2/futures-trading/internal/cache/exchange"
+	"github.com/anvh2/futures-trading/internal/helpers"
+)
+
+// validateStopPrice aligns stopPrice to tickSize and clamps it inside
+// the symbol's PERCENT_PRICE band around markPrice, so an invalid TP/SL
+// is caught here with a clear error instead of surfacing as an opaque
+// rejection from Binance mid-execution. percentFilter may be nil if the
+// symbol has no PERCENT_PRICE filter, in which case only tick alignment
+// applies.
+func validateStopPrice(markPrice, stopPrice float64, percentFilter *exchange.Filter, tickSize string) (float64, error) {
+	aligned := helpers.AlignPrice(stopPrice, tickSize)
+
+	if percentFilter == nil {
+		return aligned, nil
+	}
+
+	multiplierUp := helpers.StringToFloat(percentFilter.MultiplierUp)
+	multiplierDown := helpers.StringToFloat(percentFilter.MultiplierDown)
+
+	if multiplierUp <= 0 || multiplierDown <= 0 {
+		return aligned, nil
+	}
+
+	upperBound := markPrice * multiplierUp
+	lowerBound := markPrice * multiplierDown
+
+	switch {
+	case aligned > upperBound:
+		aligned = helpers.AlignPrice(upperBound, tickSize)
+	case aligned < lowerBound:
+		aligned = helpers.AlignPrice(lowerBound, tickSize)
+	}
+
+	if aligned > upperBound || aligned < lowerBound {
+		return 0, fmt.Errorf("orders: stop price %.8f outside percent-price band [%.8f, %.8f]", stopPrice, lowerBound, upperBound)
+	}
+
+	return aligned, nil
+}
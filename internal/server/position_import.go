@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	binancew "github.com/anvh2/futures-trading/internal/services/binance"
+	"github.com/anvh2/futures-trading/internal/state"
+)
+
+// registerPositionImportCommands wires the /positions_import Telegram
+// command, the other half of GET /v1/positions/export's disaster
+// recovery workflow. It re-attaches management to a JSON dump of
+// state.PositionRecords exported from a prior instance, re-verifying
+// each one against the exchange's current open positions before
+// adopting it: the exported SL/TP intents could be stale by the time a
+// fresh instance comes back up (a stop could have already filled, a
+// position could have been closed manually in the meantime), so only a
+// record with a live, side-matching exchange position is adopted.
+func (s *Server) registerPositionImportCommands() {
+	s.notify.Handle("/positions_import", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, errors.New("usage: /positions_import <JSON from GET /v1/positions/export>")
+		}
+
+		imported := map[string]*state.PositionRecord{}
+		if err := json.Unmarshal([]byte(strings.Join(args, "")), &imported); err != nil {
+			return nil, fmt.Errorf("failed to parse positions JSON: %w", err)
+		}
+
+		live, err := s.binance.GetPositionRisk(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+
+		liveBySymbol := make(map[string]*binancew.Position, len(live))
+		for _, position := range live {
+			if isPositionOpened(position) {
+				liveBySymbol[position.Symbol] = position
+			}
+		}
+
+		adopted := make([]string, 0, len(imported))
+		skipped := make([]string, 0, len(imported))
+
+		for symbol, record := range imported {
+			position, ok := liveBySymbol[symbol]
+			if !ok || position.PositionSide != record.Side {
+				skipped = append(skipped, symbol)
+				continue
+			}
+
+			s.tradingState.AdoptPosition(symbol, record.Side, helpers.StringToFloat(position.PositionAmt), position.EntryPrice, record.StopPrice, record.StopOrderId, record.TakeProfitPrice)
+			s.settings.UnignoreManualPosition(symbol)
+			adopted = append(adopted, symbol)
+		}
+
+		return fmt.Sprintf("adopted: %s\nskipped (no matching open exchange position): %s", strings.Join(adopted, ", "), strings.Join(skipped, ", ")), nil
+	})
+}
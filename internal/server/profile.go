@@ -0,0 +1,50 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// registerProfileCommands wires /profile, /profile_list, and
+// /reference_profile Telegram commands to settings, so the account can
+// be switched between named risk profiles (e.g. "defensive" for
+// weekends/news, "aggressive" for high-conviction periods) without a
+// restart, and an external backtest/optimization pipeline's latest
+// result can be pointed at for report.Report's weekly drift check.
+func (s *Server) registerProfileCommands() {
+	s.notify.Handle("/profile", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, errors.New("usage: /profile NAME")
+		}
+
+		name := strings.ToLower(args[0])
+		if err := s.settings.SetProfile(name); err != nil {
+			return nil, err
+		}
+
+		return "switched to profile " + name, nil
+	})
+
+	s.notify.Handle("/profile_list", func(ctx context.Context, args []string) (interface{}, error) {
+		names := make([]string, 0, len(s.settings.Profiles))
+		for name := range s.settings.Profiles {
+			names = append(names, name)
+		}
+
+		return strings.Join(names, ", "), nil
+	})
+
+	s.notify.Handle("/reference_profile", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return s.settings.ReferenceProfile, nil
+		}
+
+		name := strings.ToLower(args[0])
+		if err := s.settings.SetReferenceProfile(name); err != nil {
+			return nil, err
+		}
+
+		return "weekly parameter drift now compares live settings against profile " + name, nil
+	})
+}
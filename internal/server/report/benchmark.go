@@ -0,0 +1,171 @@
+package report
+
+import (
+	"errors"
+	"math"
+
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+var errNoBenchmarkCandle = errors.New("report: no cached candle covers the trade's holding period")
+
+// Benchmark is one Settings.BenchmarkSymbols comparison against the
+// trades it was computed from: Alpha is the strategy's mean return in
+// excess of what Beta times the benchmark's mean return would predict,
+// Beta is the strategy's sensitivity to the benchmark's return, and
+// Correlation is the Pearson correlation between the two return series.
+// Samples is how many trades contributed. A symbol with fewer than 2
+// contributing trades, or whose benchmark returns have zero variance,
+// gets no Benchmark at all.
+type Benchmark struct {
+	Symbol      string
+	Samples     int
+	Alpha       float64
+	Beta        float64
+	Correlation float64
+}
+
+// computeBenchmarks compares results against each of
+// Settings.BenchmarkSymbols' buy-and-hold return over the same holding
+// periods, so strategy value-add is measurable against simply holding
+// instead of only in isolation. Each trade contributes its R-multiple
+// (PNL/RiskAmount, the same measure AvgR uses) paired with the
+// benchmark's close-to-close return over [OpenedAt, ClosedAt]; trades
+// missing RiskAmount or a benchmark candle covering their holding period
+// are skipped.
+func (s *Report) computeBenchmarks(results []*models.TradeResult) []*Benchmark {
+	benchmarks := make([]*Benchmark, 0, len(s.settings.BenchmarkSymbols))
+
+	for _, symbol := range s.settings.BenchmarkSymbols {
+		strategy, benchmark := s.pairedReturns(symbol, results)
+		if len(strategy) < 2 {
+			continue
+		}
+
+		benchVariance := variance(benchmark)
+		if benchVariance == 0 {
+			continue
+		}
+
+		beta := covariance(strategy, benchmark) / benchVariance
+
+		benchmarks = append(benchmarks, &Benchmark{
+			Symbol:      symbol,
+			Samples:     len(strategy),
+			Alpha:       mean(strategy) - beta*mean(benchmark),
+			Beta:        beta,
+			Correlation: correlation(strategy, benchmark),
+		})
+	}
+
+	return benchmarks
+}
+
+// pairedReturns returns, for every result with a RiskAmount and a
+// symbol candle covering both ends of its holding period, its
+// R-multiple return alongside symbol's close-to-close return over the
+// same period.
+func (s *Report) pairedReturns(symbol string, results []*models.TradeResult) (strategy, benchmark []float64) {
+	for _, result := range results {
+		if result.RiskAmount <= 0 || result.OpenedAt <= 0 || result.ClosedAt <= result.OpenedAt {
+			continue
+		}
+
+		open, err := s.closeAt(symbol, result.OpenedAt)
+		if err != nil {
+			continue
+		}
+
+		closed, err := s.closeAt(symbol, result.ClosedAt)
+		if err != nil || open == 0 {
+			continue
+		}
+
+		strategy = append(strategy, result.PNL/result.RiskAmount)
+		benchmark = append(benchmark, (closed-open)/open)
+	}
+
+	return strategy, benchmark
+}
+
+// closeAt returns symbol's cached candle close as of at (the latest
+// candle whose OpenTime is at or before it), on the interval configured
+// for the active trading strategy. Returns errNoBenchmarkCandle if no
+// cached candle covers at, which happens once at falls outside the
+// market cache's retention window.
+func (s *Report) closeAt(symbol string, at int64) (float64, error) {
+	summary, err := s.marketCache.CandleSummary(symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	candles, err := summary.Candles(s.settings.IntervalFor(s.settings.TradingStrategy))
+	if err != nil {
+		return 0, err
+	}
+
+	var nearest *models.Candlestick
+	for _, item := range candles.Sorted() {
+		candle, ok := item.(*models.Candlestick)
+		if !ok || candle.OpenTime > at {
+			break
+		}
+		nearest = candle
+	}
+
+	if nearest == nil {
+		return 0, errNoBenchmarkCandle
+	}
+
+	return nearest.CloseFloat(), nil
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+func variance(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	m := mean(values)
+	var sum float64
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+
+	return sum / float64(len(values))
+}
+
+func covariance(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	ma, mb := mean(a), mean(b)
+	var sum float64
+	for i := range a {
+		sum += (a[i] - ma) * (b[i] - mb)
+	}
+
+	return sum / float64(len(a))
+}
+
+func correlation(a, b []float64) float64 {
+	denom := math.Sqrt(variance(a)) * math.Sqrt(variance(b))
+	if denom == 0 {
+		return 0
+	}
+
+	return covariance(a, b) / denom
+}
@@ -0,0 +1,28 @@
+package report
+
+import "testing"
+
+func TestCorrelation(t *testing.T) {
+	a := []float64{1, 2, 3, 4}
+	b := []float64{2, 4, 6, 8}
+
+	if got := correlation(a, b); got < 0.999 || got > 1.001 {
+		t.Fatalf("correlation of perfectly linear series = %f, want ~1", got)
+	}
+}
+
+func TestCovarianceAndBeta(t *testing.T) {
+	strategy := []float64{0.1, 0.2, 0.3, 0.4}
+	benchmark := []float64{0.05, 0.1, 0.15, 0.2}
+
+	beta := covariance(strategy, benchmark) / variance(benchmark)
+	if got, want := beta, 2.0; got < want-0.001 || got > want+0.001 {
+		t.Fatalf("beta = %f, want %f", got, want)
+	}
+}
+
+func TestMeanEmpty(t *testing.T) {
+	if got := mean(nil); got != 0 {
+		t.Fatalf("mean(nil) = %f, want 0", got)
+	}
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// checkParameterDrift compares the account's live risk parameters
+// against settings.ReferenceProfile (the most recent best-performing
+// configuration from an external backtest/optimization run) and
+// notifies if any field has drifted past
+// ParameterDriftThresholdPercent, prompting re-optimization. A disabled
+// check (see Settings.ParameterDrift) is a silent no-op.
+func (s *Report) checkParameterDrift(ctx context.Context) {
+	drift := s.settings.ParameterDrift()
+	if len(drift) == 0 {
+		return
+	}
+
+	msg := renderDrift(s.settings.ReferenceProfile, drift)
+
+	if err := s.notify.PushNotify(ctx, viper.GetInt64("notify.channels.reports"), msg); err != nil {
+		s.logger.Error("[Report] failed to push parameter drift notification", zap.Error(err))
+	}
+}
+
+func renderDrift(reference string, drift map[string]float64) string {
+	fields := make([]string, 0, len(drift))
+	for field := range drift {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "parameter drift vs profile %q exceeds threshold, consider re-optimizing:\n", reference)
+
+	for _, field := range fields {
+		fmt.Fprintf(&b, "- %s: %.1f%% drift\n", field, drift[field])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// generate compiles the current per-symbol stats into a markdown report
+// for period, pushes a summary to Telegram, and publishes the full
+// report to ReportOutputDir and ReportWebhookURL.
+func (s *Report) generate(ctx context.Context, period string) error {
+	stats := s.collectStats()
+	benchmarks := s.computeBenchmarks(s.collectResults())
+
+	report := renderMarkdown(period, stats, benchmarks)
+
+	if err := s.writeToDir(period, report); err != nil {
+		s.logger.Error("[Report] failed to write report file", zap.String("period", period), zap.Error(err))
+	}
+
+	if err := s.pushWebhook(ctx, report); err != nil {
+		s.logger.Error("[Report] failed to push webhook", zap.String("period", period), zap.Error(err))
+	}
+
+	if err := s.notify.PushNotify(ctx, viper.GetInt64("notify.channels.reports"), renderSummary(period, stats)); err != nil {
+		s.logger.Error("[Report] failed to push notification", zap.String("period", period), zap.Error(err))
+		return err
+	}
+
+	if period == weeklyPeriod {
+		s.checkParameterDrift(ctx)
+	}
+
+	return nil
+}
+
+// collectStats gathers SymbolStats for every symbol currently tracked by
+// the exchange cache, omitting symbols with no recorded trades.
+func (s *Report) collectStats() []*models.SymbolStats {
+	stats := make([]*models.SymbolStats, 0)
+
+	for _, symbol := range s.exchangeCache.Symbols() {
+		stat := s.analyzer.SymbolStats(symbol)
+		if stat == nil {
+			continue
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats
+}
+
+// collectResults gathers every symbol's closed trades currently tracked
+// by the exchange cache, for computeBenchmarks.
+func (s *Report) collectResults() []*models.TradeResult {
+	results := make([]*models.TradeResult, 0)
+
+	for _, symbol := range s.exchangeCache.Symbols() {
+		results = append(results, s.analyzer.TradingResults(symbol)...)
+	}
+
+	return results
+}
+
+func renderMarkdown(period string, stats []*models.SymbolStats, benchmarks []*Benchmark) string {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# %s performance report\n\n", capitalize(period))
+	fmt.Fprintf(&b, "generated_at: %s\n\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "| symbol | trades | win rate | avg R | total funding | avg time in market |\n")
+	fmt.Fprintf(&b, "|---|---|---|---|---|---|\n")
+
+	for _, stat := range stats {
+		fmt.Fprintf(&b, "| %s | %d | %.2f%% | %.2f | %.4f | %s |\n",
+			stat.Symbol, stat.Trades, stat.WinRate*100, stat.AvgR, stat.TotalFunding,
+			time.Duration(stat.AvgTimeInMarket*int64(time.Millisecond)))
+	}
+
+	if len(benchmarks) > 0 {
+		fmt.Fprintf(&b, "\n## Benchmark comparison\n\n")
+		fmt.Fprintf(&b, "| benchmark | samples | alpha | beta | correlation |\n")
+		fmt.Fprintf(&b, "|---|---|---|---|---|\n")
+
+		for _, benchmark := range benchmarks {
+			fmt.Fprintf(&b, "| %s | %d | %.4f | %.2f | %.2f |\n",
+				benchmark.Symbol, benchmark.Samples, benchmark.Alpha, benchmark.Beta, benchmark.Correlation)
+		}
+	}
+
+	return b.String()
+}
+
+func renderSummary(period string, stats []*models.SymbolStats) string {
+	if len(stats) == 0 {
+		return fmt.Sprintf("%s report: no trades recorded.", capitalize(period))
+	}
+
+	var trades int
+	var winSum float64
+
+	for _, stat := range stats {
+		trades += stat.Trades
+		winSum += stat.WinRate * float64(stat.Trades)
+	}
+
+	winRate := 0.0
+	if trades > 0 {
+		winRate = winSum / float64(trades)
+	}
+
+	return fmt.Sprintf("%s report: %d symbols, %d trades, %.2f%% win rate", capitalize(period), len(stats), trades, winRate*100)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func (s *Report) writeToDir(period, report string) error {
+	if s.settings.ReportOutputDir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.settings.ReportOutputDir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.md", period, time.Now().UTC().Format("2006-01-02T15-04-05"))
+	return os.WriteFile(filepath.Join(s.settings.ReportOutputDir, name), []byte(report), 0o644)
+}
+
+func (s *Report) pushWebhook(ctx context.Context, report string) error {
+	if s.settings.ReportWebhookURL == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.settings.ReportWebhookURL, strings.NewReader(report))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "text/markdown")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
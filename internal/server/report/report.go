@@ -0,0 +1,45 @@
+package report
+
+import (
+	"github.com/anvh2/futures-trading/internal/cache"
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/server/analyzer"
+	"github.com/anvh2/futures-trading/internal/services/telegram"
+	"github.com/anvh2/futures-trading/internal/settings"
+)
+
+// Report periodically compiles per-symbol performance stats into
+// daily/weekly reports, pushing a summary to Telegram and the full
+// report to ReportOutputDir (and ReportWebhookURL, if configured).
+type Report struct {
+	logger        *logger.Logger
+	notify        *telegram.TelegramBot
+	analyzer      *analyzer.Analyzer
+	exchangeCache cache.Exchange
+	marketCache   cache.Market
+	settings      *settings.Settings
+	quitChannel   chan struct{}
+}
+
+func New(
+	logger *logger.Logger,
+	notify *telegram.TelegramBot,
+	analyzer *analyzer.Analyzer,
+	exchangeCache cache.Exchange,
+	marketCache cache.Market,
+	settings *settings.Settings,
+) *Report {
+	return &Report{
+		logger:        logger,
+		notify:        notify,
+		analyzer:      analyzer,
+		exchangeCache: exchangeCache,
+		marketCache:   marketCache,
+		settings:      settings,
+		quitChannel:   make(chan struct{}),
+	}
+}
+
+func (s *Report) Stop() {
+	close(s.quitChannel)
+}
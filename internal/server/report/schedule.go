@@ -0,0 +1,41 @@
+package report
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	dailyPeriod  = "daily"
+	weeklyPeriod = "weekly"
+)
+
+// Start schedules the daily and weekly report generation loops. Ticks
+// are measured from process start rather than aligned to wall-clock
+// midnight, matching the simple interval scheduling already used by the
+// crawler and orderer background loops.
+func (s *Report) Start() error {
+	go s.schedule(dailyPeriod, 24*time.Hour)
+	go s.schedule(weeklyPeriod, 7*24*time.Hour)
+
+	return nil
+}
+
+func (s *Report) schedule(period string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.generate(context.Background(), period); err != nil {
+				s.logger.Error("[Report] failed to generate report", zap.String("period", period), zap.Error(err))
+			}
+
+		case <-s.quitChannel:
+			return
+		}
+	}
+}
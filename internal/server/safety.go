@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// registerSafetyCommands wires /safety_disable, /safety_enable, and
+// /safety_list Telegram commands to settings, so a misbehaving
+// safety.Rule can be toggled off at runtime without a restart, and
+// /safety_incidents and /safety_ack to s.guard, so an operator can see
+// and acknowledge what it's currently tripping on, see safety.Guard.
+func (s *Server) registerSafetyCommands() {
+	s.notify.Handle("/safety_disable", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, errors.New("usage: /safety_disable RULE_NAME")
+		}
+
+		s.settings.DisableSafetyRule(args[0])
+		return "disabled safety rule " + args[0], nil
+	})
+
+	s.notify.Handle("/safety_enable", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) == 0 {
+			return nil, errors.New("usage: /safety_enable RULE_NAME")
+		}
+
+		s.settings.EnableSafetyRule(args[0])
+		return "enabled safety rule " + args[0], nil
+	})
+
+	s.notify.Handle("/safety_list", func(ctx context.Context, args []string) (interface{}, error) {
+		return strings.Join(s.settings.DisabledSafetyRules, ", "), nil
+	})
+
+	s.notify.Handle("/safety_incidents", func(ctx context.Context, args []string) (interface{}, error) {
+		incidents := s.guard.ActiveIncidents()
+		if len(incidents) == 0 {
+			return "no active safety incidents", nil
+		}
+
+		lines := make([]string, 0, len(incidents))
+		for _, incident := range incidents {
+			lines = append(lines, fmt.Sprintf("[%s] %s/%s x%d: %s", incident.State, incident.Symbol, incident.Rule, incident.Occurrences, incident.Violation.Message))
+		}
+
+		return strings.Join(lines, "\n"), nil
+	})
+
+	s.notify.Handle("/safety_ack", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) < 2 {
+			return nil, errors.New("usage: /safety_ack SYMBOL RULE_NAME")
+		}
+
+		if err := s.guard.Acknowledge(args[0], args[1]); err != nil {
+			return nil, err
+		}
+
+		return fmt.Sprintf("acknowledged %s/%s", args[0], args[1]), nil
+	})
+}
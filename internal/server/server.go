@@ -10,19 +10,26 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/anvh2/futures-trading/internal/broadcast"
 	"github.com/anvh2/futures-trading/internal/cache"
+	"github.com/anvh2/futures-trading/internal/cache/basic"
 	"github.com/anvh2/futures-trading/internal/cache/exchange"
 	"github.com/anvh2/futures-trading/internal/cache/market"
 	"github.com/anvh2/futures-trading/internal/channel"
+	"github.com/anvh2/futures-trading/internal/config"
 	"github.com/anvh2/futures-trading/internal/libs/queue"
 	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/safety"
 	"github.com/anvh2/futures-trading/internal/server/analyzer"
 	"github.com/anvh2/futures-trading/internal/server/crawler"
 	"github.com/anvh2/futures-trading/internal/server/handler"
+	"github.com/anvh2/futures-trading/internal/server/heartbeat"
 	"github.com/anvh2/futures-trading/internal/server/orderer"
+	"github.com/anvh2/futures-trading/internal/server/report"
 	"github.com/anvh2/futures-trading/internal/services/binance"
 	"github.com/anvh2/futures-trading/internal/services/telegram"
 	"github.com/anvh2/futures-trading/internal/settings"
+	"github.com/anvh2/futures-trading/internal/state"
 	pb "github.com/anvh2/futures-trading/pkg/api/v1/signal"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -40,6 +47,7 @@ type RegisterGRPCHandlerFunc func(s *grpc.Server)
 type RegisterHTTPHandlerFunc func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error)
 
 type Server struct {
+	cfg     *config.Config
 	logger  *logger.Logger
 	binance *binance.Binance
 	notify  *telegram.TelegramBot
@@ -50,11 +58,18 @@ type Server struct {
 
 	marketCache   cache.Market
 	exchangeCache cache.Exchange
+	broadcast     *broadcast.Hub
 
-	crawler  *crawler.Crawler
-	analyzer *analyzer.Analyzer
-	orderer  *orderer.Orderer
-	handler  *handler.Handler
+	killSwitch   *safety.KillSwitch
+	guard        *safety.Guard
+	tradingState *state.StateManager
+
+	crawler   *crawler.Crawler
+	analyzer  *analyzer.Analyzer
+	orderer   *orderer.Orderer
+	report    *report.Report
+	heartbeat *heartbeat.Heartbeat
+	handler   *handler.Handler
 
 	server *struct {
 		grpc *grpc.Server
@@ -70,27 +85,50 @@ type Server struct {
 }
 
 func New() *Server {
-	logger, err := logger.New(viper.GetString("trading.log_path"))
+	cfg := config.Load()
+
+	logger, err := logger.New(cfg.Trading.LogPath, cfg.Trading.LogJSON)
 	if err != nil {
 		log.Fatal("failed to init logger", err)
 	}
 
-	notify, err := telegram.NewTelegramBot(logger, viper.GetString("telegram.token"))
+	notify, err := telegram.NewTelegramBot(logger, cfg.Notify.Token)
 	if err != nil {
 		log.Fatal("failed to new chat bot", err)
 	}
 
-	binance := binance.New(logger, false)
-	market := market.NewMarket(viper.GetInt32("chart.candles.limit"))
+	binance := binance.New(logger, cfg.Binance, false)
+	market := market.NewMarket(cfg.Market.CandlesLimit)
 	exchange := exchange.New(logger)
 	handler := handler.New()
 	quit := make(chan struct{})
 
 	queue := queue.New()
 	channel := channel.New()
+	broadcast := broadcast.New()
 	settings := settings.NewDefaultSettings()
 
+	// history is shared between the analyzer, which reads it for
+	// confidence/sizing and the dashboard's SymbolStats, and the orderer,
+	// which is the only writer, recording a TradeResult once a position
+	// it closed realizes its R multiple. See analyzer.tradingHistory and
+	// orderer.recordTradeResult.
+	history := basic.NewCache()
+
+	analyzer := analyzer.New(logger, notify, market, exchange, queue, channel, broadcast, settings, history)
+
+	safetyRules, err := safety.BuildRules(settings.SafetyRules)
+	if err != nil {
+		log.Fatal("failed to build safety rules", zap.Error(err))
+	}
+
+	tradingState := state.New(logger, viper.GetString("trading.state_path"))
+	if err := tradingState.Load(); err != nil && !os.IsNotExist(err) {
+		log.Fatal("failed to load trading state", zap.Error(err))
+	}
+
 	return &Server{
+		cfg:     cfg,
 		logger:  logger,
 		binance: binance,
 		notify:  notify,
@@ -101,11 +139,18 @@ func New() *Server {
 
 		marketCache:   market,
 		exchangeCache: exchange,
+		broadcast:     broadcast,
+
+		killSwitch:   safety.NewKillSwitch(settings.KillSwitchFilePath, settings.KillSwitchEnvVar),
+		guard:        safety.NewGuard(logger, notify, settings, settings.SafetyDryRun, safetyRules...),
+		tradingState: tradingState,
 
-		crawler:  crawler.New(logger, binance, notify, market, exchange, channel),
-		analyzer: analyzer.New(logger, notify, market, exchange, queue, channel, settings),
-		orderer:  orderer.New(logger, notify, market, exchange, queue, settings),
-		handler:  handler,
+		crawler:   crawler.New(logger, binance, notify, market, exchange, channel, broadcast, settings, tradingState),
+		analyzer:  analyzer,
+		orderer:   orderer.New(logger, cfg.Binance, notify, market, exchange, queue, settings, tradingState, history),
+		report:    report.New(logger, notify, analyzer, exchange, market, settings),
+		heartbeat: heartbeat.New(logger, binance, settings),
+		handler:   handler,
 
 		server: &struct {
 			grpc *grpc.Server
@@ -125,6 +170,16 @@ func New() *Server {
 }
 
 func (s *Server) Start() error {
+	s.registerBlacklistCommands()
+	s.registerSafetyCommands()
+	s.registerProfileCommands()
+	s.registerManualPositionCommands()
+	s.registerSimulateCommands()
+	s.registerPositionImportCommands()
+	s.registerWatchCommands()
+	s.startKillSwitch()
+	s.startConfigReload()
+
 	if err := s.crawler.Start(); err != nil {
 		log.Fatal("failed to crawling data", zap.Error(err))
 	}
@@ -137,7 +192,15 @@ func (s *Server) Start() error {
 		log.Fatal("failed to start orderer", zap.Error(err))
 	}
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", viper.GetInt("server.port")))
+	if err := s.report.Start(); err != nil {
+		log.Fatal("failed to start report", zap.Error(err))
+	}
+
+	if err := s.heartbeat.Start(); err != nil {
+		log.Fatal("failed to start heartbeat", zap.Error(err))
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.cfg.Server.Port))
 	if err != nil {
 		return err
 	}
@@ -162,6 +225,8 @@ func (s *Server) Start() error {
 		s.crawler.Stop()
 		s.analyzer.Stop()
 		s.orderer.Stop()
+		s.report.Stop()
+		s.heartbeat.Stop()
 
 		close(done)
 	}()
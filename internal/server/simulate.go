@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+// registerSimulateCommands wires a Telegram command that runs a
+// hypothetical signal through s.orderer.Simulate: the exact order
+// construction, exchange-filter alignment, and risk validation a real
+// signal would go through, without submitting anything to the
+// exchange. Useful for a manual sanity check of what a given RSI/K/D
+// reading would actually produce for a symbol.
+func (s *Server) registerSimulateCommands() {
+	s.notify.Handle("/simulate_order", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) < 4 {
+			return nil, errors.New("usage: /simulate_order SYMBOL RSI K D [ATR] [CONFIDENCE]")
+		}
+
+		symbol := strings.ToUpper(args[0])
+
+		rsi, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rsi: %w", err)
+		}
+
+		k, err := strconv.ParseFloat(args[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid k: %w", err)
+		}
+
+		d, err := strconv.ParseFloat(args[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid d: %w", err)
+		}
+
+		var atr float64
+		if len(args) > 4 {
+			atr, err = strconv.ParseFloat(args[4], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid atr: %w", err)
+			}
+		}
+
+		// confidence defaults to full size, since a manual simulation
+		// has no cross-interval agreement score of its own to feed
+		// risk.Sizer with.
+		confidence := 1.0
+		if len(args) > 5 {
+			confidence, err = strconv.ParseFloat(args[5], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid confidence: %w", err)
+			}
+		}
+
+		orders, err := s.orderer.Simulate(ctx, symbol, &models.Stoch{RSI: rsi, K: k, D: d}, atr, confidence)
+		if err != nil {
+			return nil, err
+		}
+
+		lines := make([]string, 0, len(orders)+1)
+
+		var notional float64
+		for _, order := range orders {
+			if order.Price != "" {
+				notional += helpers.StringToFloat(order.Quantity) * helpers.StringToFloat(order.Price)
+			}
+			lines = append(lines, order.String())
+		}
+
+		lines = append(lines, fmt.Sprintf("entry notional: %.2f", notional))
+
+		return strings.Join(lines, "\n"), nil
+	})
+}
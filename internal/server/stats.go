@@ -0,0 +1,128 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/anvh2/futures-trading/internal/models"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSymbolStatsPath     = "/v1/symbols/stats"
+	defaultRejectionsStatsPath = "/v1/rejections/stats"
+	defaultSlippageStatsPath   = "/v1/symbols/slippage"
+)
+
+// symbolStatsHandler returns per-symbol performance stats for every
+// symbol currently tracked by the exchange cache, so underperforming
+// symbols can be removed from the watchlist with evidence. Symbols with
+// no recorded trades yet are omitted rather than returned as zeros.
+func (s *Server) symbolStatsHandler(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	stats := make([]*models.SymbolStats, 0)
+
+	for _, symbol := range s.exchangeCache.Symbols() {
+		stat := s.analyzer.SymbolStats(symbol)
+		if stat == nil {
+			continue
+		}
+
+		stats = append(stats, stat)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		s.logger.Error("[SymbolStats] failed to encode response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// slippageStatsHandler returns per-symbol entry fill slippage stats for
+// every symbol currently tracked by the exchange cache, so limit-vs-
+// market entry policy can be tuned against real execution data.
+// Symbols with no recorded fills yet are omitted rather than returned
+// as zeros.
+func (s *Server) slippageStatsHandler(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	stats := make([]*models.SlippageStats, 0)
+
+	for _, symbol := range s.exchangeCache.Symbols() {
+		stat := s.orderer.SlippageStats(symbol)
+		if stat == nil {
+			continue
+		}
+
+		stats = append(stats, stat)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		s.logger.Error("[SlippageStats] failed to encode response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// rejectionsStatsHandler returns how many trading decisions and safety
+// rule evaluations have been rejected, broken down by reason, the same
+// counts underlying the trading_rejected_decisions_total and
+// trading_guard_violations_total series on /metrics, so a human can
+// check why the bot isn't trading without reading PromQL.
+func (s *Server) rejectionsStatsHandler(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		s.logger.Error("[RejectionsStats] failed to gather metrics", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	stats := map[string]map[string]float64{
+		"rejected_decisions": countersByLabel(families, "trading_rejected_decisions_total", "reason"),
+		"guard_violations":   countersByLabel(families, "trading_guard_violations_total", "rule"),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		s.logger.Error("[RejectionsStats] failed to encode response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// countersByLabel sums metricName's counter values across families,
+// keyed by the value of labelName on each metric, so a CounterVec can
+// be rendered as a flat map without the caller walking dto structs.
+func countersByLabel(families []*dto.MetricFamily, metricName, labelName string) map[string]float64 {
+	result := make(map[string]float64)
+
+	for _, family := range families {
+		if family.GetName() != metricName {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			if metric.GetCounter() == nil {
+				continue
+			}
+
+			key := ""
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == labelName {
+					key = label.GetValue()
+					break
+				}
+			}
+
+			if key == "" {
+				continue
+			}
+
+			result[key] += metric.GetCounter().GetValue()
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/anvh2/futures-trading/internal/models"
+	"go.uber.org/zap"
+)
+
+const defaultStatusPath = "/v1/status"
+
+// StatusPosition is the dashboard-relevant subset of a
+// state.PositionRecord, for futures-trading top.
+type StatusPosition struct {
+	Symbol     string `json:"symbol"`
+	Side       string `json:"side"`
+	EntryPrice string `json:"entry_price,omitempty"`
+	StopPrice  string `json:"stop_price,omitempty"`
+}
+
+// StatusBreaker is the dashboard-relevant subset of an active
+// safety.Incident, for futures-trading top.
+type StatusBreaker struct {
+	Symbol      string `json:"symbol"`
+	Rule        string `json:"rule"`
+	Message     string `json:"message"`
+	Occurrences int    `json:"occurrences"`
+}
+
+// Status is the admin dashboard snapshot futures-trading top polls,
+// covering what an operator without the web dashboard needs to see at
+// a glance: whether trading is enabled, open positions, per-symbol
+// realized PNL,
+// how deep the decision queue is running, and any active safety
+// breaker (see safety.Guard.ActiveIncidents).
+type Status struct {
+	TradingEnabled bool                  `json:"trading_enabled"`
+	QueueDepth     int64                 `json:"queue_depth"`
+	Positions      []*StatusPosition     `json:"positions"`
+	Symbols        []*models.SymbolStats `json:"symbols"`
+	Breakers       []*StatusBreaker      `json:"breakers"`
+}
+
+// statusHandler returns the current Status snapshot for
+// futures-trading top to poll.
+func (s *Server) statusHandler(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+	status := &Status{
+		TradingEnabled: s.settings.TradingEnabled,
+		QueueDepth:     s.queue.Depth("orderer"),
+		Positions:      make([]*StatusPosition, 0),
+		Symbols:        make([]*models.SymbolStats, 0),
+		Breakers:       make([]*StatusBreaker, 0),
+	}
+
+	for symbol, position := range s.tradingState.GetState().Positions {
+		status.Positions = append(status.Positions, &StatusPosition{
+			Symbol:     symbol,
+			Side:       position.Side,
+			EntryPrice: position.EntryPrice,
+			StopPrice:  position.StopPrice,
+		})
+	}
+
+	for _, symbol := range s.exchangeCache.Symbols() {
+		stat := s.analyzer.SymbolStats(symbol)
+		if stat == nil {
+			continue
+		}
+
+		status.Symbols = append(status.Symbols, stat)
+	}
+
+	for _, incident := range s.guard.ActiveIncidents() {
+		status.Breakers = append(status.Breakers, &StatusBreaker{
+			Symbol:      incident.Symbol,
+			Rule:        incident.Rule,
+			Message:     incident.Violation.Message,
+			Occurrences: incident.Occurrences,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		s.logger.Error("[Status] failed to encode response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
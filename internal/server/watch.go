@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/broadcast"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWatchDuration = time.Minute
+	maxWatchDuration     = 10 * time.Minute
+)
+
+// registerWatchCommands wires a Telegram command that taps
+// s.broadcast's live candle/indicator feed for a symbol/interval and
+// streams updates back as they arrive, instead of the one-shot read
+// every other command does. This is broadcast.Hub's first real
+// Subscribe consumer: crawler and analyzer only ever Publish to it, see
+// internal/broadcast.
+func (s *Server) registerWatchCommands() {
+	s.notify.Handle("/watch", func(ctx context.Context, args []string) (interface{}, error) {
+		if len(args) < 2 {
+			return nil, errors.New("usage: /watch SYMBOL INTERVAL [SECONDS]")
+		}
+
+		symbol := strings.ToUpper(args[0])
+		interval := args[1]
+
+		duration := defaultWatchDuration
+		if len(args) > 2 {
+			seconds, err := strconv.Atoi(args[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid seconds: %w", err)
+			}
+			duration = time.Duration(seconds) * time.Second
+		}
+		if duration > maxWatchDuration {
+			duration = maxWatchDuration
+		}
+
+		go s.streamMarketUpdates(symbol, interval, duration)
+
+		return fmt.Sprintf("watching %s %s for %s", symbol, interval, duration), nil
+	})
+}
+
+// streamMarketUpdates subscribes to symbol/interval's broadcast.Hub
+// topic for duration and pushes each update it sees to the same
+// Telegram channel trade notifications go to. The subscription, and
+// this goroutine, end on their own once duration elapses, per
+// broadcast.Hub.Subscribe.
+func (s *Server) streamMarketUpdates(symbol, interval string, duration time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	updates := s.broadcast.Subscribe(ctx, broadcast.MarketTopic(symbol, interval))
+
+	for update := range updates {
+		b, err := json.Marshal(update)
+		if err != nil {
+			s.logger.Error("[Watch] failed to marshal market update", zap.String("symbol", symbol), zap.String("interval", interval), zap.Error(err))
+			continue
+		}
+
+		if err := s.notify.PushNotify(ctx, viper.GetInt64("notify.channels.futures_announcement"), string(b)); err != nil {
+			s.logger.Error("[Watch] failed to push market update", zap.String("symbol", symbol), zap.String("interval", interval), zap.Error(err))
+		}
+	}
+}
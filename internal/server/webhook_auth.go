@@ -0,0 +1,106 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// signalWebhookPathPrefix is the gRPC-gateway path prefix that accepts
+// externally-triggered signal input (see
+// pkg/api/v1/signal/service.pb.gw.go's /v1/signal/perform and
+// /v1/signal/settings/change patterns). Only requests under this prefix
+// are subject to withWebhookAuth; the status/metrics/debug endpoints
+// mounted alongside them on the same mux are left alone.
+const signalWebhookPathPrefix = "/v1/signal/"
+
+// webhookSignatureHeader carries the hex-encoded HMAC-SHA256 of the
+// request body, keyed by settings.WebhookSigningSecret.
+const webhookSignatureHeader = "X-Signal-Signature"
+
+// withWebhookAuth wraps next with IP allowlisting and HMAC request
+// signing for signalWebhookPathPrefix, so an externally reachable
+// /v1/signal/* endpoint can't be used to inject trading signals or
+// settings changes by anyone who can reach the port. Both checks are
+// opt-in: settings.WebhookAllowedIPs/WebhookSigningSecret left empty
+// (the default) preserves today's behavior of trusting any caller.
+func (s *Server) withWebhookAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, signalWebhookPathPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !s.webhookIPAllowed(r) {
+			s.logger.Warn("[Webhook] rejected request from disallowed IP", zap.String("path", r.URL.Path), zap.String("remote_addr", r.RemoteAddr))
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		body, err := s.verifyWebhookSignature(r)
+		if err != nil {
+			s.logger.Warn("[Webhook] rejected request with invalid signature", zap.String("path", r.URL.Path), zap.Error(err))
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// webhookIPAllowed reports whether r.RemoteAddr is permitted by
+// settings.WebhookAllowedIPs, which is always true when the list is
+// empty.
+func (s *Server) webhookIPAllowed(r *http.Request) bool {
+	allowed := s.settings.WebhookAllowedIPs
+	if len(allowed) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	for _, ip := range allowed {
+		if ip == host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifyWebhookSignature checks webhookSignatureHeader against an
+// HMAC-SHA256 of the request body keyed by settings.WebhookSigningSecret,
+// and returns the body read off r so the caller can restore it before
+// the real handler reads it again. An empty WebhookSigningSecret
+// disables the check.
+func (s *Server) verifyWebhookSignature(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.settings.WebhookSigningSecret == "" {
+		return body, nil
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.settings.WebhookSigningSecret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(r.Header.Get(webhookSignatureHeader))) {
+		return nil, errors.New("server: signature mismatch")
+	}
+
+	return body, nil
+}
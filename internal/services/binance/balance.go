@@ -0,0 +1,52 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/anvh2/futures-trading/internal/services/binance/helpers"
+)
+
+// GetBalances returns the account's wallet balance per asset, including
+// any non-USDT collateral (e.g. BNB or BTC) held against the futures
+// margin account.
+func (f *Binance) GetBalances(ctx context.Context) ([]*Balance, error) {
+	f.limiter.Wait(ctx)
+
+	fullURL := fmt.Sprintf("%s/fapi/v2/balance", f.getURL())
+
+	signed, err := helpers.Signed(http.MethodGet, fullURL, &url.Values{}, f.testnet)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, signed.FullURL, signed.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header = signed.Header
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	rawData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := []*Balance{}
+	if err := json.Unmarshal(rawData, &balances); err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
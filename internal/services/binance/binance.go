@@ -4,8 +4,8 @@ import (
 	"net/http"
 
 	"github.com/anvh2/futures-trading/internal/client"
+	"github.com/anvh2/futures-trading/internal/config"
 	"github.com/anvh2/futures-trading/internal/logger"
-	"github.com/spf13/viper"
 	"golang.org/x/time/rate"
 )
 
@@ -21,10 +21,10 @@ type Binance struct {
 	client  *http.Client
 }
 
-func New(logger *logger.Logger, testnet bool) *Binance {
+func New(logger *logger.Logger, cfg config.BinanceConfig, testnet bool) *Binance {
 	limiter := rate.NewLimiter(
-		rate.Every(viper.GetDuration("binance.rate_limit.duration")),
-		viper.GetInt("binance.rate_limit.requests"),
+		rate.Every(cfg.RateLimitDuration),
+		cfg.RateLimitRequests,
 	)
 	return &Binance{
 		limiter: limiter,
@@ -3,7 +3,9 @@ package binance
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/anvh2/futures-trading/internal/config"
 	"github.com/anvh2/futures-trading/internal/logger"
 	"github.com/joho/godotenv"
 )
@@ -15,7 +17,7 @@ var (
 func TestMain(m *testing.M) {
 	godotenv.Load("../../../.env")
 
-	test_binanceInst = New(logger.NewDev(), true)
+	test_binanceInst = New(logger.NewDev(), config.BinanceConfig{RateLimitRequests: 200, RateLimitDuration: time.Minute}, true)
 
 	os.Exit(m.Run())
 }
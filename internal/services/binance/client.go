@@ -0,0 +1,36 @@
+package binance
+
+import (
+	"context"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+// Client is the set of Binance futures operations consumed by the
+// crawler, orderer, heartbeat and server. *Binance implements it
+// against the live (or testnet) REST API; simulated.Exchange
+// implements it against cached market data, so paper trading,
+// backtests, and E2E tests can depend on Client instead of wiring up
+// ad-hoc mocks around *Binance.
+type Client interface {
+	GetExchangeInfo(ctx context.Context) (*futures.ExchangeInfo, error)
+	GetCurrentPrice(ctx context.Context, symbol string) (*futures.SymbolPrice, error)
+	GetPremiumIndex(ctx context.Context, symbol string) (*futures.PremiumIndex, error)
+	GetBookTicker(ctx context.Context, symbol string) (*futures.BookTicker, error)
+	GetCandlesticks(ctx context.Context, symbol, interval string, limit int, startTime, endTime int64) ([]*binance.Kline, error)
+	GetLeverageBracket(ctx context.Context, symbol string) ([]*LeverageBracket, error)
+	CancelOrder(ctx context.Context, symbol string, orderId int64) (*CreateOrderResp, error)
+	GetPositionRisk(ctx context.Context, symbol string) ([]*Position, error)
+	GetOpenPositions(ctx context.Context) ([]*Position, error)
+	GetOpenOrders(ctx context.Context, symbol string) ([]*Order, error)
+	OpenOrders(ctx context.Context, orders []*models.Order) ([]*CreateOrderResp, error)
+	GetListenKey(ctx context.Context) (string, error)
+	GetPositionMode(ctx context.Context) (*PositionMode, error)
+	SetPositionMode(ctx context.Context, dualSidePosition bool) error
+	GetBalances(ctx context.Context) ([]*Balance, error)
+	GetCommissionRate(ctx context.Context, symbol string) (*CommissionRate, error)
+}
+
+var _ Client = (*Binance)(nil)
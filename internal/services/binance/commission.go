@@ -0,0 +1,55 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/anvh2/futures-trading/internal/services/binance/helpers"
+)
+
+// GetCommissionRate returns the account's actual maker/taker commission
+// rate for symbol, reflecting volume/BNB fee discounts rather than
+// Binance's default tier.
+func (f *Binance) GetCommissionRate(ctx context.Context, symbol string) (*CommissionRate, error) {
+	f.limiter.Wait(ctx)
+
+	fullURL := fmt.Sprintf("%s/fapi/v1/commissionRate", f.getURL())
+
+	params := &url.Values{}
+	params.Add("symbol", symbol)
+
+	signed, err := helpers.Signed(http.MethodGet, fullURL, params, f.testnet)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, signed.FullURL, signed.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header = signed.Header
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	rawData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := &CommissionRate{}
+	if err := json.Unmarshal(rawData, rate); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
@@ -47,7 +47,7 @@ func Signed(method, fullURL string, params *url.Values, testnet bool) (*SignedDa
 
 	if params != nil {
 		switch method {
-		case http.MethodGet:
+		case http.MethodGet, http.MethodDelete:
 			queryStr = params.Encode()
 
 		case http.MethodPost:
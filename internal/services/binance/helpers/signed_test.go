@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSignedEncodesDeleteParamsIntoQuery(t *testing.T) {
+	t.Setenv("TEST_API_KEY", "key")
+	t.Setenv("TEST_SECRET_KEY", "secret")
+
+	params := &url.Values{}
+	params.Set("symbol", "BTCUSDT")
+	params.Set("orderId", "123")
+
+	signed, err := Signed(http.MethodDelete, "https://testnet.binancefuture.com/fapi/v1/order", params, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(signed.FullURL, "symbol=BTCUSDT") {
+		t.Errorf("expected FullURL to carry symbol, got %v", signed.FullURL)
+	}
+
+	if !strings.Contains(signed.FullURL, "orderId=123") {
+		t.Errorf("expected FullURL to carry orderId, got %v", signed.FullURL)
+	}
+
+	if !strings.Contains(signed.FullURL, "signature=") {
+		t.Errorf("expected FullURL to carry a signature, got %v", signed.FullURL)
+	}
+}
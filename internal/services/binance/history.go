@@ -0,0 +1,79 @@
+package binance
+
+import (
+	"context"
+	"sort"
+
+	"github.com/adshao/go-binance/v2"
+)
+
+// maxKlinesPerRequest is Binance's documented per-request cap for the
+// klines/continuousKlines endpoints, used as the default page size for
+// FetchCandlestickHistory when the caller doesn't pass one.
+const maxKlinesPerRequest = 1500
+
+// candlestickSource is the subset of Client FetchCandlestickHistory
+// pages through, so a test can stub paging behavior without
+// implementing all of Client. Both *Binance and simulated.Exchange
+// satisfy it already, being full Client implementations.
+type candlestickSource interface {
+	GetCandlesticks(ctx context.Context, symbol, interval string, limit int, startTime, endTime int64) ([]*binance.Kline, error)
+}
+
+// FetchCandlestickHistory pages GetCandlesticks forward from startTime
+// until endTime (0 meaning up to the present), fetching up to
+// pageLimit candles per request, deduplicating by OpenTime and
+// returning the result strictly ordered oldest-first. GetCandlesticks
+// alone only fetches a single page capped at limit; deep history (a
+// new symbol's full backfill, a backtest replaying a multi-month
+// range) needs more than one page can hold.
+//
+// It's built against candlestickSource rather than *Binance directly,
+// so a downloader or backtester depending on Client (see Client's doc
+// comment) pages through either the live API or simulated.Exchange the
+// same way.
+func FetchCandlestickHistory(ctx context.Context, client candlestickSource, symbol, interval string, startTime, endTime int64, pageLimit int) ([]*binance.Kline, error) {
+	if pageLimit <= 0 || pageLimit > maxKlinesPerRequest {
+		pageLimit = maxKlinesPerRequest
+	}
+
+	seen := make(map[int64]bool)
+	var history []*binance.Kline
+
+	cursor := startTime
+
+	for {
+		page, err := client.GetCandlesticks(ctx, symbol, interval, pageLimit, cursor, endTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		added := 0
+
+		for _, kline := range page {
+			if seen[kline.OpenTime] {
+				continue
+			}
+
+			seen[kline.OpenTime] = true
+			history = append(history, kline)
+			added++
+		}
+
+		last := page[len(page)-1]
+
+		if added == 0 || len(page) < pageLimit || (endTime != 0 && last.CloseTime >= endTime) {
+			break
+		}
+
+		cursor = last.CloseTime + 1
+	}
+
+	sort.Slice(history, func(i, j int) bool { return history[i].OpenTime < history[j].OpenTime })
+
+	return history, nil
+}
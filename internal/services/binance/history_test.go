@@ -0,0 +1,95 @@
+package binance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adshao/go-binance/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedKlineSource replays pages of klines keyed by startTime, so
+// FetchCandlestickHistory's pagination loop can be exercised without
+// the real, network-dependent Binance API.
+type pagedKlineSource struct {
+	pageLimit int
+	// all is the full, ordered set of klines the fake server holds,
+	// sliced into pages of pageLimit starting from whichever startTime
+	// the caller requests.
+	all []*binance.Kline
+	// overlap, if set, starts each page this many milliseconds before the
+	// requested startTime, so consecutive pages repeat klines.
+	overlap int64
+}
+
+func (s *pagedKlineSource) GetCandlesticks(ctx context.Context, symbol, interval string, limit int, startTime, endTime int64) ([]*binance.Kline, error) {
+	var page []*binance.Kline
+
+	for _, kline := range s.all {
+		if kline.OpenTime < startTime-s.overlap {
+			continue
+		}
+		if endTime != 0 && kline.OpenTime > endTime {
+			continue
+		}
+
+		page = append(page, kline)
+		if len(page) >= s.pageLimit {
+			break
+		}
+	}
+
+	return page, nil
+}
+
+func klineAt(openTime int64) *binance.Kline {
+	return &binance.Kline{OpenTime: openTime, CloseTime: openTime + 999}
+}
+
+func TestFetchCandlestickHistoryPaginatesAndOrders(t *testing.T) {
+	var all []*binance.Kline
+	for i := int64(0); i < 25; i++ {
+		all = append(all, klineAt(i*1000))
+	}
+
+	source := &pagedKlineSource{pageLimit: 10, all: all}
+
+	history, err := FetchCandlestickHistory(context.Background(), source, "BTCUSDT", "1m", 0, 0, 10)
+	assert.Nil(t, err)
+	assert.Len(t, history, 25)
+
+	for i, kline := range history {
+		assert.Equal(t, int64(i)*1000, kline.OpenTime)
+	}
+}
+
+func TestFetchCandlestickHistoryStopsAtEndTime(t *testing.T) {
+	var all []*binance.Kline
+	for i := int64(0); i < 25; i++ {
+		all = append(all, klineAt(i*1000))
+	}
+
+	source := &pagedKlineSource{pageLimit: 10, all: all}
+
+	history, err := FetchCandlestickHistory(context.Background(), source, "BTCUSDT", "1m", 0, 12000, 10)
+	assert.Nil(t, err)
+
+	if assert.NotEmpty(t, history) {
+		last := history[len(history)-1]
+		assert.LessOrEqual(t, last.OpenTime, int64(12000))
+	}
+}
+
+func TestFetchCandlestickHistoryDedupsOverlappingPages(t *testing.T) {
+	var all []*binance.Kline
+	for i := int64(0); i < 10; i++ {
+		all = append(all, klineAt(i*1000))
+	}
+
+	// each page after the first repeats the previous page's last kline
+	source := &pagedKlineSource{pageLimit: 5, overlap: 1000, all: all}
+
+	history, err := FetchCandlestickHistory(context.Background(), source, "BTCUSDT", "1m", 0, 0, 5)
+	assert.Nil(t, err)
+	assert.Len(t, history, 10)
+}
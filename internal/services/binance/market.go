@@ -44,6 +44,41 @@ func (f *Binance) GetExchangeInfo(ctx context.Context) (*futures.ExchangeInfo, e
 	return res, nil
 }
 
+// GetSystemStatus polls Binance's exchange-wide maintenance status.
+// Like GetCandlesticks, it hits www.binance.com directly rather than
+// f.getURL()'s fapi/testnet hosts, since this status isn't served per
+// market.
+func (f *Binance) GetSystemStatus(ctx context.Context) (*SystemStatus, error) {
+	f.limiter.Wait(ctx)
+
+	fullURL := "https://www.binance.com/sapi/v1/system/status"
+
+	req, err := http.NewRequest(http.MethodGet, fullURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	rawData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &SystemStatus{}
+	if err := json.Unmarshal(rawData, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (f *Binance) GetCurrentPrice(ctx context.Context, symbol string) (*futures.SymbolPrice, error) {
 	f.limiter.Wait(ctx)
 
@@ -76,6 +111,68 @@ func (f *Binance) GetCurrentPrice(ctx context.Context, symbol string) (*futures.
 	return price, nil
 }
 
+func (f *Binance) GetPremiumIndex(ctx context.Context, symbol string) (*futures.PremiumIndex, error) {
+	f.limiter.Wait(ctx)
+
+	url := fmt.Sprintf("%s/fapi/v1/premiumIndex?symbol=%s", f.getURL(), symbol)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	premium := &futures.PremiumIndex{}
+	if err := json.Unmarshal(data, premium); err != nil {
+		return nil, err
+	}
+
+	return premium, nil
+}
+
+func (f *Binance) GetBookTicker(ctx context.Context, symbol string) (*futures.BookTicker, error) {
+	f.limiter.Wait(ctx)
+
+	url := fmt.Sprintf("%s/fapi/v1/ticker/bookTicker?symbol=%s", f.getURL(), symbol)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := &futures.BookTicker{}
+	if err := json.Unmarshal(data, ticker); err != nil {
+		return nil, err
+	}
+
+	return ticker, nil
+}
+
 func (f *Binance) GetCandlesticks(ctx context.Context, symbol, interval string, limit int, startTime, endTime int64) ([]*binance.Kline, error) {
 	f.limiter.Wait(ctx)
 
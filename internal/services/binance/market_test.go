@@ -26,6 +26,12 @@ func TestGetExchangeInfo(t *testing.T) {
 	}
 }
 
+func TestGetSystemStatus(t *testing.T) {
+	resp, err := test_binanceInst.GetSystemStatus(context.Background())
+	assert.Nil(t, err)
+	fmt.Println(resp)
+}
+
 func TestGetCurrentPrice(t *testing.T) {
 	resp, err := test_binanceInst.GetCurrentPrice(context.Background(), "BTCUSDT")
 	assert.Nil(t, err)
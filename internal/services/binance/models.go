@@ -50,6 +50,47 @@ type Position struct {
 	IsolatedWallet   string `json:"isolatedWallet"`
 }
 
+// PositionMode is the account-wide setting controlling whether a
+// position is tracked as one combined (one-way) or two opposing
+// (hedge: LONG + SHORT) positions per symbol. Orders placed with an
+// explicit PositionSide (see models.Order), as this bot always does,
+// require DualSidePosition to be true.
+type PositionMode struct {
+	*Error
+	DualSidePosition bool `json:"dualSidePosition"`
+}
+
+// CommissionRate is the account's actual maker/taker fee tier for one
+// symbol, which can sit below Binance's default 0.02%/0.04% once
+// volume/BNB discounts apply. See Client.GetCommissionRate.
+type CommissionRate struct {
+	*Error
+	Symbol              string `json:"symbol"`
+	MakerCommissionRate string `json:"makerCommissionRate"`
+	TakerCommissionRate string `json:"takerCommissionRate"`
+}
+
+// SystemStatus is Binance's exchange-wide maintenance status. It's
+// served from the general system/status endpoint rather than a
+// futures-specific one, since a maintenance window tends to take down
+// order placement across markets together. See Binance.GetSystemStatus.
+type SystemStatus struct {
+	*Error
+	Status int `json:"status"` // 0: normal, 1: system maintenance
+}
+
+// Balance is one asset's wallet balance in a multi-asset margin
+// account, e.g. BNB or BTC held as collateral alongside USDT. See
+// Client.GetBalances.
+type Balance struct {
+	*Error
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"`
+	CrossWalletBalance string `json:"crossWalletBalance"`
+	CrossUnPnl         string `json:"crossUnPnl"`
+	AvailableBalance   string `json:"availableBalance"`
+}
+
 type Order struct {
 	Error            *Error
 	Symbol           string                   `json:"symbol"`
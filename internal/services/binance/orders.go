@@ -1,22 +1,60 @@
 package binance
 
 import (
-	"bytes"
 	"context"
-	"crypto/hmac"
-	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"os"
-	"time"
+	"strconv"
 
 	"github.com/anvh2/futures-trading/internal/models"
 	"github.com/anvh2/futures-trading/internal/services/binance/helpers"
 )
 
+func (f *Binance) CancelOrder(ctx context.Context, symbol string, orderId int64) (*CreateOrderResp, error) {
+	f.limiter.Wait(ctx)
+
+	fullURL := fmt.Sprintf("%s/fapi/v1/order", f.getURL())
+
+	params := &url.Values{
+		"symbol":  []string{symbol},
+		"orderId": []string{fmt.Sprint(orderId)},
+	}
+
+	signed, err := helpers.Signed(http.MethodDelete, fullURL, params, f.testnet)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, signed.FullURL, signed.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header = signed.Header
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	rawData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &CreateOrderResp{}
+	if err := json.Unmarshal(rawData, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
 func (f *Binance) GetPositionRisk(ctx context.Context, symbol string) ([]*Position, error) {
 	f.limiter.Wait(ctx)
 
@@ -70,21 +108,78 @@ func (f *Binance) GetPositionRisk(ctx context.Context, symbol string) ([]*Positi
 	return res, nil
 }
 
+// GetOpenPositions returns every position across all symbols with a
+// non-zero amount, for heartbeat/watchdog reporting where the caller
+// needs a book-wide count rather than one symbol's risk.
+func (f *Binance) GetOpenPositions(ctx context.Context) ([]*Position, error) {
+	f.limiter.Wait(ctx)
+
+	fullURL := fmt.Sprintf("%s/fapi/v2/positionRisk", f.getURL())
+
+	signed, err := helpers.Signed(http.MethodGet, fullURL, &url.Values{}, f.testnet)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, signed.FullURL, signed.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header = signed.Header
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("error: %v", resp.Status)
+	}
+
+	rawData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]*Position, 0)
+	if err := json.Unmarshal(rawData, &positions); err != nil {
+		return nil, err
+	}
+
+	open := make([]*Position, 0, len(positions))
+	for _, position := range positions {
+		if amount, err := strconv.ParseFloat(position.PositionAmt, 64); err == nil && amount != 0 {
+			open = append(open, position)
+		}
+	}
+
+	return open, nil
+}
+
 func (f *Binance) GetOpenOrders(ctx context.Context, symbol string) ([]*Order, error) {
 	f.limiter.Wait(ctx)
 
 	fullURL := fmt.Sprintf("%s/fapi/v1/openOrders", f.getURL())
 
-	req, err := http.NewRequest(http.MethodGet, fullURL, nil)
+	params := &url.Values{
+		"symbol": []string{symbol},
+	}
+
+	signed, err := helpers.Signed(http.MethodGet, fullURL, params, f.testnet)
 	if err != nil {
 		return nil, err
 	}
 
-	req = req.WithContext(ctx)
+	req, err := http.NewRequest(http.MethodGet, signed.FullURL, signed.Body)
+	if err != nil {
+		return nil, err
+	}
 
-	query := req.URL.Query()
-	query.Add("symbol", symbol)
-	query.Add("timestamp", fmt.Sprint(time.Now().UnixMilli()))
+	req = req.WithContext(ctx)
+	req.Header = signed.Header
 
 	resp, err := f.client.Do(req)
 	if err != nil {
@@ -169,48 +264,22 @@ func (f *Binance) OpenOrders(ctx context.Context, orders []*models.Order) ([]*Cr
 		return nil, err
 	}
 
-	params := map[string]interface{}{
-		"batchOrders": string(b),
-		"timestamp":   time.Now().UnixMilli(),
-	}
-
-	form := &url.Values{
+	params := &url.Values{
 		"batchOrders": []string{string(b)},
-		"timestamp":   []string{fmt.Sprint(time.Now().UnixMilli())},
 	}
 
-	for key, val := range params {
-		form.Set(key, fmt.Sprint(val))
-	}
-
-	bodyStr := form.Encode()
-	body := bytes.NewBufferString(bodyStr)
-
-	header := http.Header{}
-	header.Set("X-MBX-APIKEY", os.Getenv("TEST_API_KEY"))
-	header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	mac := hmac.New(sha256.New, []byte(os.Getenv("TEST_SECRET_KEY")))
-	_, err = mac.Write([]byte(bodyStr))
+	signed, err := helpers.Signed(http.MethodPost, fullURL, params, f.testnet)
 	if err != nil {
 		return nil, err
 	}
 
-	v := url.Values{}
-	v.Set("signature", fmt.Sprintf("%x", (mac.Sum(nil))))
-
-	queryString := v.Encode()
-	if queryString != "" {
-		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
-	}
-
-	req, err := http.NewRequest(http.MethodPost, fullURL, body)
+	req, err := http.NewRequest(http.MethodPost, signed.FullURL, signed.Body)
 	if err != nil {
 		return nil, err
 	}
 
 	req = req.WithContext(ctx)
-	req.Header = header
+	req.Header = signed.Header
 
 	resp, err := f.client.Do(req)
 	if err != nil {
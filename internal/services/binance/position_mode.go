@@ -0,0 +1,99 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/anvh2/futures-trading/internal/services/binance/helpers"
+)
+
+// GetPositionMode reports whether the account currently runs in hedge
+// mode (DualSidePosition true, tracking LONG and SHORT separately per
+// symbol) or one-way mode.
+func (f *Binance) GetPositionMode(ctx context.Context) (*PositionMode, error) {
+	f.limiter.Wait(ctx)
+
+	fullURL := fmt.Sprintf("%s/fapi/v1/positionSide/dual", f.getURL())
+
+	signed, err := helpers.Signed(http.MethodGet, fullURL, &url.Values{}, f.testnet)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, signed.FullURL, signed.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header = signed.Header
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	rawData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := &PositionMode{}
+	if err := json.Unmarshal(rawData, mode); err != nil {
+		return nil, err
+	}
+
+	return mode, nil
+}
+
+// SetPositionMode switches the account between hedge mode
+// (dualSidePosition true) and one-way mode.
+func (f *Binance) SetPositionMode(ctx context.Context, dualSidePosition bool) error {
+	f.limiter.Wait(ctx)
+
+	fullURL := fmt.Sprintf("%s/fapi/v1/positionSide/dual", f.getURL())
+
+	params := &url.Values{
+		"dualSidePosition": []string{fmt.Sprint(dualSidePosition)},
+	}
+
+	signed, err := helpers.Signed(http.MethodPost, fullURL, params, f.testnet)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, signed.FullURL, signed.Body)
+	if err != nil {
+		return err
+	}
+
+	req = req.WithContext(ctx)
+	req.Header = signed.Header
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	rawData, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	result := &PositionMode{Error: &Error{}}
+	if err := json.Unmarshal(rawData, result.Error); err != nil {
+		return err
+	}
+
+	if result.Error.Code != 0 {
+		return fmt.Errorf("binance: failed to set position mode: %s", result.Error.Msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// Package simulated implements binance.Client against cached market
+// data instead of the live Binance REST API: orders match against the
+// symbol's latest cached candle, fees and funding accrue the same way
+// they would live, and fills can be partial and latency-delayed. Paper
+// trading, backtests, and E2E tests depend on it instead of the real
+// client or ad-hoc mocks.
+package simulated
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/anvh2/futures-trading/internal/cache"
+	"github.com/anvh2/futures-trading/internal/libs/fault"
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/services/binance"
+	"github.com/anvh2/futures-trading/internal/settings"
+)
+
+var errNoMarketData = errors.New("simulated: no cached market data for symbol")
+
+// position is one symbol's net exposure. amount is positive for a long
+// position and negative for a short one.
+type position struct {
+	amount     float64
+	entryPrice string
+	leverage   int
+	// fees accumulates trading fees and funding payments charged
+	// against the position, subtracted from its unrealized PnL.
+	fees float64
+}
+
+// Exchange is an in-process, in-memory Binance futures exchange backed
+// by cache.Market. It implements binance.Client, so it drops in
+// wherever *binance.Binance does.
+type Exchange struct {
+	logger   *logger.Logger
+	market   cache.Market
+	settings *settings.Settings
+
+	mutex            sync.Mutex
+	positions        map[string]*position
+	orders           map[int64]*binance.Order
+	nextOrderID      int64
+	dualSidePosition bool
+
+	faults *fault.Injector
+}
+
+// New returns an Exchange that reads prices from market and
+// fees/fill behavior/latency/fault rates from settings
+// (SimulatedTradingFeeRate, SimulatedPartialFillRatio,
+// SimulatedLatencyMillis, SimulatedFault*).
+func New(logger *logger.Logger, market cache.Market, settings *settings.Settings) *Exchange {
+	return &Exchange{
+		logger:           logger,
+		market:           market,
+		settings:         settings,
+		positions:        make(map[string]*position),
+		orders:           make(map[int64]*binance.Order),
+		dualSidePosition: true,
+		faults: fault.New(
+			settings.SimulatedFaultErrorRate,
+			settings.SimulatedFaultTimeoutRate,
+			settings.SimulatedFaultMalformedRate,
+			time.Duration(settings.SimulatedFaultTimeoutMillis)*time.Millisecond,
+		),
+	}
+}
+
+var _ binance.Client = (*Exchange)(nil)
+
+// wait blocks for SimulatedLatencyMillis, or until ctx is done, so
+// callers that depend on real-world order latency exercise the same
+// timing against the simulator as they would live.
+func (e *Exchange) wait(ctx context.Context) {
+	latency := time.Duration(e.settings.SimulatedLatencyMillis) * time.Millisecond
+	if latency <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// injectFault blocks for the configured timeout fault (if it trips)
+// and then reports whether the error fault trips, so callers can
+// short-circuit with a synthetic failure the same way a flaky
+// dependency would.
+func (e *Exchange) injectFault(ctx context.Context) error {
+	e.faults.MaybeDelay(ctx)
+
+	if e.faults.ShouldError() {
+		return fault.ErrInjected
+	}
+
+	return nil
+}
+
+// markPrice returns the close of the latest cached candle for symbol,
+// on the interval configured for the active trading strategy.
+func (e *Exchange) markPrice(symbol string) (float64, error) {
+	candle, err := e.markCandle(symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseFloat(candle.Close, 64)
+}
+
+// markCandle returns the latest cached candle for symbol, on the
+// interval configured for the active trading strategy, so fills can be
+// checked against its traded range and volume instead of only its
+// close, see applyFill.
+func (e *Exchange) markCandle(symbol string) (*models.Candlestick, error) {
+	summary, err := e.market.CandleSummary(symbol)
+	if err != nil {
+		return nil, errNoMarketData
+	}
+
+	candles, err := summary.Candles(e.settings.IntervalFor(e.settings.TradingStrategy))
+	if err != nil {
+		return nil, errNoMarketData
+	}
+
+	tail, idx := candles.Tail()
+	if idx < 0 {
+		return nil, errNoMarketData
+	}
+
+	candle, ok := tail.(*models.Candlestick)
+	if !ok {
+		return nil, errNoMarketData
+	}
+
+	return candle, nil
+}
@@ -0,0 +1,31 @@
+package simulated
+
+// fundingRate is the flat funding rate applied to every simulated
+// position; real funding rates oscillate with spot/perp premium, which
+// isn't modeled here, but a small constant rate is enough to exercise
+// code paths that react to periodic funding charges.
+const fundingRate = 0.0001
+
+// ApplyFunding charges or pays fundingRate against every open
+// position's notional, the simulated analogue of a funding settlement.
+// It isn't called automatically, callers (e.g. a backtest driver)
+// invoke it once per funding interval.
+func (e *Exchange) ApplyFunding() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for symbol, pos := range e.positions {
+		if pos.amount == 0 {
+			continue
+		}
+
+		price, err := e.markPrice(symbol)
+		if err != nil {
+			continue
+		}
+
+		// a long pays funding when the rate is positive, a short
+		// receives it, mirroring Binance's perpetual futures contract.
+		pos.fees += pos.amount * price * fundingRate
+	}
+}
@@ -0,0 +1,38 @@
+package simulated
+
+import (
+	"context"
+
+	"github.com/anvh2/futures-trading/internal/services/binance"
+)
+
+// GetLeverageBracket returns a single static bracket covering
+// PreferLeverageBrackets' first value (20 if it is empty); the
+// simulator doesn't model
+// Binance's notional-tiered margin requirements.
+func (e *Exchange) GetLeverageBracket(ctx context.Context, symbol string) ([]*binance.LeverageBracket, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	leverage := 20
+	if len(e.settings.PreferLeverageBrackets) > 0 {
+		leverage = e.settings.PreferLeverageBrackets[0]
+	}
+
+	return []*binance.LeverageBracket{
+		{
+			Symbol: symbol,
+			Brackets: []binance.Bracket{
+				{
+					Bracket:          1,
+					InitialLeverage:  leverage,
+					NotionalCap:      1_000_000,
+					NotionalFloor:    0,
+					MaintMarginRatio: 0.01,
+				},
+			},
+		},
+	}, nil
+}
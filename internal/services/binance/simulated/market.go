@@ -0,0 +1,157 @@
+package simulated
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	adshaobinance "github.com/adshao/go-binance/v2"
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/models"
+)
+
+// GetExchangeInfo returns a minimal ExchangeInfo; the simulator has no
+// notion of exchange-wide filters or per-symbol trading rules, only
+// cached candle data, so Symbols is always empty.
+func (e *Exchange) GetExchangeInfo(ctx context.Context) (*futures.ExchangeInfo, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	return &futures.ExchangeInfo{
+		Timezone:   "UTC",
+		ServerTime: time.Now().UnixMilli(),
+	}, nil
+}
+
+// GetCurrentPrice returns the symbol's latest cached candle close.
+func (e *Exchange) GetCurrentPrice(ctx context.Context, symbol string) (*futures.SymbolPrice, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	if e.faults.ShouldMalform() {
+		return &futures.SymbolPrice{Symbol: symbol, Price: "not-a-number"}, nil
+	}
+
+	price, err := e.markPrice(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &futures.SymbolPrice{
+		Symbol: symbol,
+		Price:  fmt.Sprintf("%f", price),
+	}, nil
+}
+
+// GetPremiumIndex returns the symbol's latest cached candle close as
+// its mark price, alongside the flat simulated funding rate, see
+// ApplyFunding.
+func (e *Exchange) GetPremiumIndex(ctx context.Context, symbol string) (*futures.PremiumIndex, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	if e.faults.ShouldMalform() {
+		return &futures.PremiumIndex{Symbol: symbol, MarkPrice: "not-a-number"}, nil
+	}
+
+	price, err := e.markPrice(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &futures.PremiumIndex{
+		Symbol:          symbol,
+		MarkPrice:       fmt.Sprintf("%f", price),
+		LastFundingRate: fmt.Sprintf("%f", fundingRate),
+		NextFundingTime: time.Now().Add(8 * time.Hour).UnixMilli(),
+		Time:            time.Now().UnixMilli(),
+	}, nil
+}
+
+// GetBookTicker synthesizes a one-tick-wide book around the symbol's
+// latest cached candle close; the simulator has no real order book.
+func (e *Exchange) GetBookTicker(ctx context.Context, symbol string) (*futures.BookTicker, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	if e.faults.ShouldMalform() {
+		return &futures.BookTicker{Symbol: symbol, BidPrice: "not-a-number", AskPrice: "not-a-number"}, nil
+	}
+
+	price, err := e.markPrice(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	spread := price * 0.0001
+
+	return &futures.BookTicker{
+		Symbol:      symbol,
+		BidPrice:    fmt.Sprintf("%f", price-spread),
+		AskPrice:    fmt.Sprintf("%f", price+spread),
+		BidQuantity: "1",
+		AskQuantity: "1",
+	}, nil
+}
+
+// GetCandlesticks replays the symbol's cached candles instead of
+// fetching from Binance, so backtests run entirely against data
+// already collected by the crawler. Only candles opened within
+// [startTime, endTime] are returned; a zero bound is left open.
+func (e *Exchange) GetCandlesticks(ctx context.Context, symbol, interval string, limit int, startTime, endTime int64) ([]*adshaobinance.Kline, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	summary, err := e.market.CandleSummary(symbol)
+	if err != nil {
+		return nil, errNoMarketData
+	}
+
+	candles, err := summary.Candles(interval)
+	if err != nil {
+		return nil, errNoMarketData
+	}
+
+	raw := candles.Read()
+	klines := make([]*adshaobinance.Kline, 0, len(raw))
+
+	for _, item := range raw {
+		candle, ok := item.(*models.Candlestick)
+		if !ok {
+			continue
+		}
+
+		if startTime != 0 && candle.OpenTime < startTime {
+			continue
+		}
+		if endTime != 0 && candle.OpenTime > endTime {
+			continue
+		}
+
+		if limit > 0 && len(klines) >= limit {
+			break
+		}
+
+		klines = append(klines, &adshaobinance.Kline{
+			OpenTime:  candle.OpenTime,
+			Open:      candle.Open,
+			High:      candle.High,
+			Low:       candle.Low,
+			Close:     candle.Close,
+			Volume:    candle.Volume,
+			CloseTime: candle.CloseTime,
+		})
+	}
+
+	return klines, nil
+}
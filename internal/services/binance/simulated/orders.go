@@ -0,0 +1,305 @@
+package simulated
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/adshao/go-binance/v2/futures"
+	"github.com/anvh2/futures-trading/internal/models"
+	"github.com/anvh2/futures-trading/internal/services/binance"
+)
+
+// OpenOrders fills each order against the symbol's cached candle. A
+// fraction of the quantity equal to SimulatedPartialFillRatio fills
+// immediately, minus SimulatedTradingFeeRate in fees; the remainder, if
+// any, is left resting as an open order. When
+// SimulatedOrderAwareFillsEnabled, resolveFill additionally accounts
+// for order type (LIMIT trade-through, STOP_MARKET/TAKE_PROFIT_MARKET
+// slippage) and caps the fill by the candle's traded volume, see
+// resolveFill.
+func (e *Exchange) OpenOrders(ctx context.Context, orders []*models.Order) ([]*binance.CreateOrderResp, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	resp := make([]*binance.CreateOrderResp, 0, len(orders))
+
+	for _, order := range orders {
+		if e.faults.ShouldMalform() {
+			resp = append(resp, &binance.CreateOrderResp{
+				Symbol: order.Symbol,
+				Status: "MALFORMED",
+				Price:  "not-a-number",
+			})
+			continue
+		}
+
+		candle, err := e.markCandle(order.Symbol)
+		if err != nil {
+			resp = append(resp, &binance.CreateOrderResp{
+				Error:  &binance.Error{Msg: err.Error()},
+				Symbol: order.Symbol,
+			})
+			continue
+		}
+
+		quantity, _ := strconv.ParseFloat(order.Quantity, 64)
+		price, filled := e.resolveFill(order, candle, quantity)
+		remaining := quantity - filled
+
+		e.applyFill(order, price, filled)
+
+		status := "FILLED"
+		if remaining > 0 {
+			status = string(futures.OrderStatusTypePartiallyFilled)
+			if filled <= 0 {
+				status = string(futures.OrderStatusTypeNew)
+			}
+
+			e.nextOrderID++
+			order.OrderId = fmt.Sprint(e.nextOrderID)
+			e.orders[e.nextOrderID] = &binance.Order{
+				Symbol:           order.Symbol,
+				OrderID:          e.nextOrderID,
+				Price:            order.Price,
+				OrigQuantity:     fmt.Sprint(quantity),
+				ExecutedQuantity: fmt.Sprint(filled),
+				Status:           futures.OrderStatusType(status),
+				Side:             order.Side,
+				PositionSide:     order.PositionSide,
+				Type:             order.OrderType,
+			}
+		}
+
+		resp = append(resp, &binance.CreateOrderResp{
+			Symbol:        order.Symbol,
+			Status:        status,
+			ClientOrderId: order.NewClientOrderId,
+			Price:         fmt.Sprintf("%f", price),
+			AvgPrice:      fmt.Sprintf("%f", price),
+			OrigQty:       fmt.Sprint(quantity),
+			ExecutedQty:   fmt.Sprint(filled),
+			Side:          string(order.Side),
+			PositionSide:  string(order.PositionSide),
+			Type:          string(order.OrderType),
+			ReduceOnly:    order.ReduceOnly,
+			ClosePosition: order.ClosePosition,
+		})
+	}
+
+	return resp, nil
+}
+
+// resolveFill decides order's fill price and filled quantity against
+// candle. With SimulatedOrderAwareFillsEnabled false, it reproduces the
+// original behavior: every order fills at candle's close, limited only
+// by SimulatedPartialFillRatio.
+//
+// With it true: a LIMIT order only fills if candle's range actually
+// traded through Price (it fills at Price itself, the same way a resting
+// limit order would on the real exchange); a STOP_MARKET or
+// TAKE_PROFIT_MARKET order only fills if candle's range reached
+// StopPrice, and then fills at StopPrice adverse-adjusted by
+// SimulatedStopSlippagePercent, the same way a triggered stop would slip
+// past its trigger in a fast market. An order that didn't trade through
+// rests fully unfilled. Whatever quantity would otherwise fill is
+// additionally capped by SimulatedMaxFillVolumeRatio of candle's traded
+// Volume, so one order can't fill more size than the market traded.
+func (e *Exchange) resolveFill(order *models.Order, candle *models.Candlestick, quantity float64) (price, filled float64) {
+	fillRatio := e.settings.SimulatedPartialFillRatio
+	if fillRatio <= 0 || fillRatio > 1 {
+		fillRatio = 1
+	}
+
+	price = candle.CloseFloat()
+	tradedThrough := true
+
+	if e.settings.SimulatedOrderAwareFillsEnabled {
+		switch order.OrderType {
+		case futures.OrderTypeLimit:
+			price, _ = strconv.ParseFloat(order.Price, 64)
+			if order.Side == futures.SideTypeBuy {
+				tradedThrough = candle.LowFloat() <= price
+			} else {
+				tradedThrough = candle.HighFloat() >= price
+			}
+
+		case futures.OrderTypeStopMarket, futures.OrderTypeTakeProfitMarket:
+			trigger, _ := strconv.ParseFloat(order.StopPrice, 64)
+			slippage := e.settings.SimulatedStopSlippagePercent
+
+			if order.Side == futures.SideTypeBuy {
+				tradedThrough = candle.HighFloat() >= trigger
+				price = trigger * (1 + slippage)
+			} else {
+				tradedThrough = candle.LowFloat() <= trigger
+				price = trigger * (1 - slippage)
+			}
+		}
+	}
+
+	if !tradedThrough {
+		return price, 0
+	}
+
+	filled = quantity * fillRatio
+
+	if e.settings.SimulatedOrderAwareFillsEnabled && e.settings.SimulatedMaxFillVolumeRatio > 0 {
+		if volumeCap := candle.VolumeFloat() * e.settings.SimulatedMaxFillVolumeRatio; volumeCap < filled {
+			filled = volumeCap
+		}
+	}
+
+	return price, filled
+}
+
+// applyFill books filled units of order at price into the symbol's
+// position, charging SimulatedTradingFeeRate as a fee against it the
+// same way a real fill would.
+func (e *Exchange) applyFill(order *models.Order, price, filled float64) {
+	if filled <= 0 {
+		return
+	}
+
+	fee := filled * price * e.settings.SimulatedTradingFeeRate
+
+	signed := filled
+	if order.Side == futures.SideTypeSell {
+		signed = -filled
+	}
+
+	pos := e.positions[order.Symbol]
+	if pos == nil {
+		pos = &position{}
+		e.positions[order.Symbol] = pos
+	}
+
+	entryPrice, _ := strconv.ParseFloat(pos.entryPrice, 64)
+	newAmount := pos.amount + signed
+
+	switch {
+	case pos.amount == 0:
+		entryPrice = price
+	case (pos.amount > 0) == (signed > 0):
+		// adding to the position: roll the new fill into a
+		// quantity-weighted average entry price
+		entryPrice = (entryPrice*pos.amount + price*signed) / newAmount
+	}
+
+	pos.entryPrice = fmt.Sprintf("%f", entryPrice)
+	pos.amount = newAmount
+	pos.fees += fee
+}
+
+// CancelOrder removes a resting order, if it exists.
+func (e *Exchange) CancelOrder(ctx context.Context, symbol string, orderId int64) (*binance.CreateOrderResp, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	order, ok := e.orders[orderId]
+	if !ok {
+		return nil, fmt.Errorf("simulated: order %d not found", orderId)
+	}
+
+	delete(e.orders, orderId)
+
+	return &binance.CreateOrderResp{
+		OrderId:      int(orderId),
+		Symbol:       symbol,
+		Status:       "CANCELED",
+		Side:         string(order.Side),
+		PositionSide: string(order.PositionSide),
+	}, nil
+}
+
+// GetPositionRisk returns the simulated position for symbol, or an
+// empty slice when nothing is open.
+func (e *Exchange) GetPositionRisk(ctx context.Context, symbol string) ([]*binance.Position, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	pos := e.positions[symbol]
+	if pos == nil || pos.amount == 0 {
+		return []*binance.Position{}, nil
+	}
+
+	return []*binance.Position{e.toPosition(symbol, pos)}, nil
+}
+
+// GetOpenPositions returns every symbol currently holding a non-zero
+// simulated position.
+func (e *Exchange) GetOpenPositions(ctx context.Context) ([]*binance.Position, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	open := make([]*binance.Position, 0, len(e.positions))
+	for symbol, pos := range e.positions {
+		if pos.amount == 0 {
+			continue
+		}
+		open = append(open, e.toPosition(symbol, pos))
+	}
+
+	return open, nil
+}
+
+func (e *Exchange) toPosition(symbol string, pos *position) *binance.Position {
+	markPrice, _ := e.markPrice(symbol)
+
+	positionSide := futures.PositionSideTypeLong
+	if pos.amount < 0 {
+		positionSide = futures.PositionSideTypeShort
+	}
+
+	entryPrice, _ := strconv.ParseFloat(pos.entryPrice, 64)
+	unrealized := (markPrice-entryPrice)*pos.amount - pos.fees
+
+	return &binance.Position{
+		Symbol:           symbol,
+		EntryPrice:       pos.entryPrice,
+		MarkPrice:        fmt.Sprintf("%f", markPrice),
+		PositionAmt:      fmt.Sprintf("%f", pos.amount),
+		PositionSide:     string(positionSide),
+		UnRealizedProfit: fmt.Sprintf("%f", unrealized),
+	}
+}
+
+// GetOpenOrders returns resting (partially filled) orders for symbol.
+func (e *Exchange) GetOpenOrders(ctx context.Context, symbol string) ([]*binance.Order, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	open := make([]*binance.Order, 0)
+	for _, order := range e.orders {
+		if order.Symbol == symbol {
+			open = append(open, order)
+		}
+	}
+
+	return open, nil
+}
@@ -0,0 +1,88 @@
+package simulated
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/anvh2/futures-trading/internal/services/binance"
+)
+
+// GetListenKey returns a fixed key; the simulator has no user data
+// stream for callers to listen on.
+func (e *Exchange) GetListenKey(ctx context.Context) (string, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return "", err
+	}
+
+	return "simulated-listen-key", nil
+}
+
+// GetPositionMode returns the simulated account's hedge/one-way
+// setting, true (hedge) by default, since orders.go always places
+// orders with an explicit PositionSide.
+func (e *Exchange) GetPositionMode(ctx context.Context) (*binance.PositionMode, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return &binance.PositionMode{DualSidePosition: e.dualSidePosition}, nil
+}
+
+// SetPositionMode switches the simulated account between hedge and
+// one-way mode.
+func (e *Exchange) SetPositionMode(ctx context.Context, dualSidePosition bool) error {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return err
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.dualSidePosition = dualSidePosition
+	return nil
+}
+
+// GetBalances returns the simulated account's fixed USDT wallet
+// balance (settings.SimulatedStartingBalance). The simulator doesn't
+// model non-USDT collateral, so it never reports BNB/BTC balances.
+func (e *Exchange) GetBalances(ctx context.Context) ([]*binance.Balance, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	balance := strconv.FormatFloat(e.settings.SimulatedStartingBalance, 'f', -1, 64)
+
+	return []*binance.Balance{
+		{
+			Asset:              "USDT",
+			Balance:            balance,
+			CrossWalletBalance: balance,
+			AvailableBalance:   balance,
+		},
+	}, nil
+}
+
+// GetCommissionRate returns settings.SimulatedTradingFeeRate as both
+// the maker and taker rate, matching the single flat fee orders.go
+// charges every fill regardless of whether it rested on the book.
+func (e *Exchange) GetCommissionRate(ctx context.Context, symbol string) (*binance.CommissionRate, error) {
+	e.wait(ctx)
+	if err := e.injectFault(ctx); err != nil {
+		return nil, err
+	}
+
+	rate := strconv.FormatFloat(e.settings.SimulatedTradingFeeRate, 'f', -1, 64)
+
+	return &binance.CommissionRate{
+		Symbol:              symbol,
+		MakerCommissionRate: rate,
+		TakerCommissionRate: rate,
+	}, nil
+}
@@ -22,6 +22,9 @@ var _ telegram.Notify = &NotifyMock{}
 //			PushNotifyFunc: func(ctx context.Context, chatId int64, message string) error {
 //				panic("mock out the PushNotify method")
 //			},
+//			PushPhotoFunc: func(ctx context.Context, chatId int64, photo []byte, caption string) error {
+//				panic("mock out the PushPhoto method")
+//			},
 //			StopFunc: func()  {
 //				panic("mock out the Stop method")
 //			},
@@ -35,6 +38,9 @@ type NotifyMock struct {
 	// PushNotifyFunc mocks the PushNotify method.
 	PushNotifyFunc func(ctx context.Context, chatId int64, message string) error
 
+	// PushPhotoFunc mocks the PushPhoto method.
+	PushPhotoFunc func(ctx context.Context, chatId int64, photo []byte, caption string) error
+
 	// StopFunc mocks the Stop method.
 	StopFunc func()
 
@@ -49,11 +55,23 @@ type NotifyMock struct {
 			// Message is the message argument value.
 			Message string
 		}
+		// PushPhoto holds details about calls to the PushPhoto method.
+		PushPhoto []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ChatId is the chatId argument value.
+			ChatId int64
+			// Photo is the photo argument value.
+			Photo []byte
+			// Caption is the caption argument value.
+			Caption string
+		}
 		// Stop holds details about calls to the Stop method.
 		Stop []struct {
 		}
 	}
 	lockPushNotify sync.RWMutex
+	lockPushPhoto  sync.RWMutex
 	lockStop       sync.RWMutex
 }
 
@@ -97,6 +115,50 @@ func (mock *NotifyMock) PushNotifyCalls() []struct {
 	return calls
 }
 
+// PushPhoto calls PushPhotoFunc.
+func (mock *NotifyMock) PushPhoto(ctx context.Context, chatId int64, photo []byte, caption string) error {
+	if mock.PushPhotoFunc == nil {
+		panic("NotifyMock.PushPhotoFunc: method is nil but Notify.PushPhoto was just called")
+	}
+	callInfo := struct {
+		Ctx     context.Context
+		ChatId  int64
+		Photo   []byte
+		Caption string
+	}{
+		Ctx:     ctx,
+		ChatId:  chatId,
+		Photo:   photo,
+		Caption: caption,
+	}
+	mock.lockPushPhoto.Lock()
+	mock.calls.PushPhoto = append(mock.calls.PushPhoto, callInfo)
+	mock.lockPushPhoto.Unlock()
+	return mock.PushPhotoFunc(ctx, chatId, photo, caption)
+}
+
+// PushPhotoCalls gets all the calls that were made to PushPhoto.
+// Check the length with:
+//
+//	len(mockedNotify.PushPhotoCalls())
+func (mock *NotifyMock) PushPhotoCalls() []struct {
+	Ctx     context.Context
+	ChatId  int64
+	Photo   []byte
+	Caption string
+} {
+	var calls []struct {
+		Ctx     context.Context
+		ChatId  int64
+		Photo   []byte
+		Caption string
+	}
+	mock.lockPushPhoto.RLock()
+	calls = mock.calls.PushPhoto
+	mock.lockPushPhoto.RUnlock()
+	return calls
+}
+
 // Stop calls StopFunc.
 func (mock *NotifyMock) Stop() {
 	if mock.StopFunc == nil {
@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"bytes"
 	"context"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 //go:generate moq -pkg telemock -out ./mocks/telegram_mock.go . Notify
 type Notify interface {
 	PushNotify(ctx context.Context, chatId int64, message string) error
+	PushPhoto(ctx context.Context, chatId int64, photo []byte, caption string) error
 	Stop()
 }
 
@@ -64,6 +66,23 @@ func (t *TelegramBot) PushNotify(ctx context.Context, chatId int64, message stri
 	return nil
 }
 
+// PushPhoto sends photo (PNG-encoded image bytes) to chatId with
+// caption, e.g. orderer's post-trade review chart attached to the
+// trade-completed notification.
+func (t *TelegramBot) PushPhoto(ctx context.Context, chatId int64, photo []byte, caption string) error {
+	resp, err := t.bot.Send(&tb.User{ID: chatId}, &tb.Photo{
+		File:    tb.FromReader(bytes.NewReader(photo)),
+		Caption: caption,
+	})
+	if err != nil {
+		t.logger.Error("[TelegramBot] failed to send photo", zap.String("caption", caption), zap.Error(err))
+		return err
+	}
+
+	t.logger.Info("[TelegramBot] push photo success", zap.String("caption", caption), zap.Any("messageId", resp.ID))
+	return nil
+}
+
 func (t *TelegramBot) Stop() {
 	t.bot.Stop()
 }
@@ -1,17 +1,36 @@
 package settings
 
-import "github.com/anvh2/futures-trading/internal/services/binance"
+import (
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/anvh2/futures-trading/internal/helpers"
+	"github.com/anvh2/futures-trading/internal/services/binance"
+)
 
 var (
 	DefaultSettings = NewDefaultSettings()
 )
 
+// RuleConfig composes one safety.Rule from a type name and its
+// parameters, so rules can be added/tuned from config instead of
+// requiring a Go code change, see safety.Build/BuildRules.
+type RuleConfig struct {
+	Type   string                 `json:"type"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
 type TradingStrategy byte
 
 const (
 	TradingStrategyInvalid = iota
 	TradingStrategyInstantNoodles
 	TradingStrategyDollarCostAveraging // recommended within 1h interval
+	// TradingStrategyFundingWindowScalp trades mean reversion around an
+	// extreme funding print, see internal/strategy.FundingWindowScalp and
+	// orderer.create.
+	TradingStrategyFundingWindowScalp
 )
 
 type PNL struct {
@@ -24,28 +43,734 @@ type PNL struct {
 }
 
 type Settings struct {
-	SignalDisabled         bool            `json:"signal_disabled,omitempty"`
-	TradingEnabled         bool            `json:"trading_enabled,omitempty"`
-	TradingCost            float64         `json:"trading_cost,omitempty"`
-	TradingInterval        string          `json:"trading_interval,omitempty"`
-	TradingStrategy        TradingStrategy `json:"trading_strategy,omitempty"`
-	MaxPositionsDaily      int32           `json:"max_positions_daily,omitempty"`
-	MaxPositionsPerTime    int32           `json:"max_positions_per_time,omitempty"`
-	PreferLeverageBrackets []int           `json:"prefer_leverage_brackets,omitempty"`
-	LongPNL                *PNL            `json:"long_pnl,omitempty"`
-	ShortPNL               *PNL            `json:"short_pnl,omitempty"`
+	SignalDisabled  bool            `json:"signal_disabled,omitempty"`
+	TradingEnabled  bool            `json:"trading_enabled,omitempty"`
+	TradingCost     float64         `json:"trading_cost,omitempty"`
+	TradingInterval string          `json:"trading_interval,omitempty"`
+	TradingStrategy TradingStrategy `json:"trading_strategy,omitempty"`
+	// TradingIntervals overrides TradingInterval per strategy, so e.g. DCA
+	// can run on a slower interval than the scoring strategy at the same
+	// time. Missing entries fall back to TradingInterval, see IntervalFor.
+	TradingIntervals       map[TradingStrategy]string `json:"trading_intervals,omitempty"`
+	MaxPositionsDaily      int32                      `json:"max_positions_daily,omitempty"`
+	MaxPositionsPerTime    int32                      `json:"max_positions_per_time,omitempty"`
+	PreferLeverageBrackets []int                      `json:"prefer_leverage_brackets,omitempty"`
+	LongPNL                *PNL                       `json:"long_pnl,omitempty"`
+	ShortPNL               *PNL                       `json:"short_pnl,omitempty"`
+	// PositionSizingMode selects the Sizer used to turn TradingCost into
+	// an order quantity, see internal/risk. 0 = confidence, 1 = kelly.
+	PositionSizingMode byte `json:"position_sizing_mode,omitempty"`
+	// EngineVersion identifies the default scoring engine/parameter set.
+	EngineVersion string `json:"engine_version,omitempty"`
+	// AltEngineVersion is routed a percentage of signals, see AltEngineRolloutPercent.
+	AltEngineVersion string `json:"alt_engine_version,omitempty"`
+	// AltEngineRolloutPercent is the percentage (0-100) of symbols routed
+	// to AltEngineVersion instead of EngineVersion.
+	AltEngineRolloutPercent float64 `json:"alt_engine_rollout_percent,omitempty"`
+	// MaxPositionsPerSymbolHourly/Daily and MaxPositionsGlobalHourly/Daily
+	// throttle how many new positions may be opened, see internal/risk.Throttle.
+	// Zero means unlimited for that scope/window.
+	MaxPositionsPerSymbolHourly int `json:"max_positions_per_symbol_hourly,omitempty"`
+	MaxPositionsPerSymbolDaily  int `json:"max_positions_per_symbol_daily,omitempty"`
+	MaxPositionsGlobalHourly    int `json:"max_positions_global_hourly,omitempty"`
+	MaxPositionsGlobalDaily     int `json:"max_positions_global_daily,omitempty"`
+	// MaxTotalExposureRatio rejects a new position once the account's
+	// total open notional, across every position GetPositionRisk reports
+	// (including ones opened manually), reaches this multiple of its
+	// actual equity, see Orderer.checkGlobalExposure. <= 0 disables the
+	// check.
+	MaxTotalExposureRatio float64 `json:"max_total_exposure_ratio,omitempty"`
+	// CorrelatedSymbolGroups names groups of symbols whose price moves
+	// are highly correlated (e.g. "majors": BTCUSDT, ETHUSDT, SOLUSDT),
+	// see risk.ClusterTracker. A symbol entered within
+	// ClusterWindowMinutes of another entry in its group has its size
+	// reduced, since the two positions are, in practice, one combined
+	// exposure rather than two independent ones. A symbol missing from
+	// every group is never clustered.
+	CorrelatedSymbolGroups map[string][]string `json:"correlated_symbol_groups,omitempty"`
+	// ClusterWindowMinutes is the window risk.ClusterTracker looks back
+	// across when counting recent entries on a correlated symbol's
+	// group. <= 0 disables clustering entirely.
+	ClusterWindowMinutes int `json:"cluster_window_minutes,omitempty"`
+	// MaxDrawdownPercent is the drawdown from peak equity at which
+	// risk.EquityCurve.SizeMultiplier fully deleverages new positions to
+	// zero size, see state.StateManager.UpdateEquity.
+	MaxDrawdownPercent float64 `json:"max_drawdown_percent,omitempty"`
+	// FundingAvoidanceMinutes delays opening a position this many
+	// minutes before a funding settlement that would pay away from it,
+	// see risk.InFundingAvoidanceWindow. 0 disables the check.
+	FundingAvoidanceMinutes int `json:"funding_avoidance_minutes,omitempty"`
+	// MinSymbolListingDays blocks trading on symbols listed fewer than
+	// this many days ago, see safety.NewListingRule. 0 disables the check.
+	MinSymbolListingDays int `json:"min_symbol_listing_days,omitempty"`
+	// MaxSpreadBps and MinBookSizeRatio reject an entry whose book
+	// ticker looks too thin to fill cleanly, see risk.LiquidityConfig.
+	MaxSpreadBps     float64 `json:"max_spread_bps,omitempty"`
+	MinBookSizeRatio float64 `json:"min_book_size_ratio,omitempty"`
+	// MinRiskRewardRatio rejects a decision whose take-profit distance
+	// isn't worth at least this multiple of its stop-loss distance, see
+	// risk.ValidateStops. <= 0 disables the check.
+	MinRiskRewardRatio float64 `json:"min_risk_reward_ratio,omitempty"`
+	// MaxStopDistanceATRMultiple rejects a decision whose stop-loss sits
+	// further from entry than this multiple of models.Oscillator.ATR,
+	// see risk.ValidateStops. <= 0 disables the check.
+	MaxStopDistanceATRMultiple float64 `json:"max_stop_distance_atr_multiple,omitempty"`
+	// StopDistanceATRMultipleByStrategy overrides MaxStopDistanceATRMultiple
+	// per strategy, see StopDistanceATRMultipleFor. A funding-window scalp
+	// wants a noticeably tighter cap than this system's other strategies,
+	// since it's meant to be held only until a funding print cools off.
+	StopDistanceATRMultipleByStrategy map[TradingStrategy]float64 `json:"stop_distance_atr_multiple_by_strategy,omitempty"`
+	// MinStopDistanceTicks is the minimum distance, in multiples of the
+	// symbol's tick size, a stop-loss/take-profit trigger must sit away
+	// from entry, see risk.WidenStopDistance. A trigger closer than this
+	// risks Binance rejecting the order as "would immediately trigger"
+	// (error -2021) once mark price drifts by even a tick. orderer.create
+	// widens a too-close stop-loss outward and shrinks its order
+	// quantity by the same factor, so the dollar risk of the trade
+	// (distance times quantity) doesn't change. <= 0 disables the check.
+	MinStopDistanceTicks float64 `json:"min_stop_distance_ticks,omitempty"`
+	// RequireStopLoss rejects a decision that places no stop-loss order
+	// at all, see risk.ValidateStops. False preserves this system's
+	// current behavior under TradingStrategyInstantNoodles, which
+	// manages exits via evaluateExits instead of an exchange-side stop.
+	RequireStopLoss bool `json:"require_stop_loss,omitempty"`
+	// NotificationRateLimitMinutes is how long a duplicate signal for the
+	// same symbol is suppressed after one was already sent, see
+	// internal/server/analyzer.process. 0 disables rate limiting.
+	NotificationRateLimitMinutes int `json:"notification_rate_limit_minutes,omitempty"`
+	// NotificationBypassConfidence lets a signal through the rate limit
+	// regardless of how recently one was sent, when its Confidence meets
+	// or exceeds this value. 0 disables the bypass.
+	NotificationBypassConfidence float64 `json:"notification_bypass_confidence,omitempty"`
+	// DigestModeEnabled batches signals whose Confidence is below
+	// DigestConfidenceThreshold into a single message flushed every
+	// DigestIntervalMinutes, instead of paging on every low-priority
+	// signal.
+	DigestModeEnabled         bool    `json:"digest_mode_enabled,omitempty"`
+	DigestIntervalMinutes     int     `json:"digest_interval_minutes,omitempty"`
+	DigestConfidenceThreshold float64 `json:"digest_confidence_threshold,omitempty"`
+	// ReportOutputDir is the directory the daily/weekly performance
+	// reports are written to, see internal/server/report.
+	ReportOutputDir string `json:"report_output_dir,omitempty"`
+	// ReportWebhookURL, if set, receives an HTTP POST of each generated
+	// report in addition to the file written to ReportOutputDir.
+	ReportWebhookURL string `json:"report_webhook_url,omitempty"`
+	// BenchmarkSymbols are the buy-and-hold symbols the daily/weekly
+	// performance report compares strategy performance against (alpha,
+	// beta, correlation), see report.computeBenchmarks. Empty disables
+	// the comparison.
+	BenchmarkSymbols []string `json:"benchmark_symbols,omitempty"`
+	// RebalanceCheckIntervalMinutes is how often orderer.Orderer reviews
+	// held positions for portfolio-rebalancing suggestions (trimming an
+	// oversized winner, closing a stagnant position), see
+	// orderer.reviewRebalancing. Zero disables the check.
+	RebalanceCheckIntervalMinutes int `json:"rebalance_check_interval_minutes,omitempty"`
+	// RebalanceTrimRMultiple is the unrealized-profit R-multiple (PNL
+	// relative to the position's entry-to-stop risk) above which a held
+	// position is flagged an oversized winner worth trimming against its
+	// risk budget. Zero (or negative) disables the check.
+	RebalanceTrimRMultiple float64 `json:"rebalance_trim_r_multiple,omitempty"`
+	// RebalanceStagnantMinutes and RebalanceStagnantRMultiple together
+	// flag a position stagnant: held at least RebalanceStagnantMinutes
+	// while its unrealized R has stayed within
+	// +/-RebalanceStagnantRMultiple, i.e. sitting near breakeven without
+	// its stop or take-profit ever triggering, tying up a risk-budget
+	// slot for no return. Zero RebalanceStagnantMinutes disables the
+	// check.
+	RebalanceStagnantMinutes   int     `json:"rebalance_stagnant_minutes,omitempty"`
+	RebalanceStagnantRMultiple float64 `json:"rebalance_stagnant_r_multiple,omitempty"`
+	// RebalanceAutoExecute, if set, acts on a rebalancing suggestion
+	// instead of only notifying: an oversized winner is trimmed by half,
+	// a stagnant position is closed outright. False, the default, leaves
+	// both decisions to the operator.
+	RebalanceAutoExecute bool `json:"rebalance_auto_execute,omitempty"`
+	// ShadowScoreIntervalMinutes is how often orderer.Orderer scores
+	// pending shadow decisions (rejected signals that made it past
+	// appraisal, see risk.ShadowTracker) against how price actually moved
+	// and logs an updated risk.OpportunityCostReport comparing them to
+	// real closed trades. Zero disables shadow tracking entirely.
+	ShadowScoreIntervalMinutes int `json:"shadow_score_interval_minutes,omitempty"`
+	// ShadowForwardWindowMinutes is how long after a rejection
+	// risk.ShadowTracker waits before scoring it against the symbol's
+	// current price, giving the signal time to have played out one way
+	// or the other.
+	ShadowForwardWindowMinutes int `json:"shadow_forward_window_minutes,omitempty"`
+	// FundingScalpThresholdRate is the absolute funding rate beyond which
+	// a print counts as extreme enough to fade, see
+	// strategy.FundingWindowScalp. Binance funding typically sits within
+	// +/-0.01%, so this is usually set well above that baseline. <= 0
+	// disables the strategy entirely, regardless of TradingStrategy.
+	FundingScalpThresholdRate float64 `json:"funding_scalp_threshold_rate,omitempty"`
+	// FundingScalpTargetATRMultiple sizes the strategy's take-profit
+	// distance as a multiple of models.Oscillator.ATR, see
+	// strategy.FundingWindowScalp. Its stop-loss distance instead reuses
+	// StopDistanceATRMultipleFor(TradingStrategyFundingWindowScalp), so the
+	// same number both sizes the stop and caps it under risk.ValidateStops.
+	FundingScalpTargetATRMultiple float64 `json:"funding_scalp_target_atr_multiple,omitempty"`
+	// FundingScalpMaxPositionsHourly and FundingScalpMaxPositionsDaily
+	// gate TradingStrategyFundingWindowScalp behind its own risk budget,
+	// independent of MaxPositionsGlobalHourly/Daily, see
+	// Orderer.fundingScalpThrottle. Zero means unlimited for that window.
+	FundingScalpMaxPositionsHourly int `json:"funding_scalp_max_positions_hourly,omitempty"`
+	FundingScalpMaxPositionsDaily  int `json:"funding_scalp_max_positions_daily,omitempty"`
+	// WebhookAllowedIPs, if non-empty, restricts the inbound signal
+	// webhook endpoints (/v1/signal/perform, /v1/signal/settings/change,
+	// see internal/server.withWebhookAuth) to callers whose remote
+	// address matches one of these IPs. Empty allows any caller, which
+	// is today's behavior.
+	WebhookAllowedIPs []string `json:"webhook_allowed_ips,omitempty"`
+	// WebhookSigningSecret, if set, requires the inbound signal webhook
+	// endpoints to carry a valid HMAC-SHA256 signature of the request
+	// body in the X-Signal-Signature header, keyed by this secret. Empty
+	// disables signature validation, which is today's behavior.
+	WebhookSigningSecret string `json:"webhook_signing_secret,omitempty"`
+	// HeartbeatURL, if set, receives an HTTP POST every
+	// HeartbeatIntervalSeconds with system status and open position
+	// count, see internal/server/heartbeat. Point this at a
+	// healthchecks.io-style ping URL so a missed heartbeat pages an
+	// external watchdog instead of relying on the bot to notice its own
+	// death. Empty disables the heartbeat.
+	HeartbeatURL string `json:"heartbeat_url,omitempty"`
+	// HeartbeatIntervalSeconds is how often the heartbeat fires.
+	HeartbeatIntervalSeconds int `json:"heartbeat_interval_seconds,omitempty"`
+	// ExitEvaluationIntervalSeconds is how often orderer.Orderer
+	// re-scores held positions for an exit, see orderer.evaluateExits.
+	// Zero disables exit evaluation, leaving positions to close only via
+	// their take-profit/stop orders.
+	ExitEvaluationIntervalSeconds int `json:"exit_evaluation_interval_seconds,omitempty"`
+	// MaxHoldingPeriodMinutes overrides DefaultMaxHoldingPeriodMinutes
+	// per strategy, see MaxHoldingPeriodFor. A ranging strategy can sit
+	// open for weeks eating funding without one of its indicators ever
+	// flipping, so this is a second, time-based trigger evaluateExits
+	// checks alongside bias flip/confidence collapse.
+	MaxHoldingPeriodMinutes map[TradingStrategy]int `json:"max_holding_period_minutes,omitempty"`
+	// DefaultMaxHoldingPeriodMinutes is the holding period limit used for
+	// a strategy missing from MaxHoldingPeriodMinutes. 0 disables the
+	// check entirely.
+	DefaultMaxHoldingPeriodMinutes int `json:"default_max_holding_period_minutes,omitempty"`
+	// MinWarmupCandles overrides DefaultMinWarmupCandles per strategy,
+	// see MinWarmupCandlesFor. The analyzer skips computing indicators
+	// for an interval until it has at least this many closed candles
+	// cached, so a symbol right after startup or a new listing doesn't
+	// get scored off a handful of candles before RSI/KDJ/ADX/Bollinger
+	// Band state has had a chance to stabilize.
+	MinWarmupCandles map[TradingStrategy]int `json:"min_warmup_candles,omitempty"`
+	// DefaultMinWarmupCandles is the warm-up requirement used for a
+	// strategy missing from MinWarmupCandles. 0 disables the check
+	// entirely.
+	DefaultMinWarmupCandles int `json:"default_min_warmup_candles,omitempty"`
+	// BreakEvenRMultiple is the unrealized profit, as a multiple of the
+	// position's initial entry-to-stop distance (its "R"), at which
+	// evaluateExits moves the stop-loss to entry (plus
+	// BreakEvenFeeBufferFraction). <= 0 disables break-even automation.
+	BreakEvenRMultiple float64 `json:"break_even_r_multiple,omitempty"`
+	// BreakEvenFeeBufferFraction pads the break-even stop price beyond raw
+	// entry, as a fraction of entry price, so the position still covers
+	// its round-trip trading fees once the stop fills. Used as the
+	// maker+taker commission rate fallback until orderer.Orderer's
+	// commission tracking has fetched the account's actual rate for a
+	// symbol, see Orderer.commissionRate. Named Fraction rather than
+	// Percent since it's on the same 0-1 scale as a commission rate, not
+	// a 0-100 percentage, see helpers.Fraction.
+	BreakEvenFeeBufferFraction helpers.Fraction `json:"break_even_fee_buffer_fraction,omitempty"`
+	// KillSwitchFilePath and KillSwitchEnvVar are polled every few
+	// seconds by the kill-switch watcher; either being set trips an
+	// emergency stop, see internal/safety.KillSwitch. A last-resort
+	// control for when the API and Telegram command interface are
+	// unreachable. Empty disables the respective trigger.
+	KillSwitchFilePath string `json:"kill_switch_file_path,omitempty"`
+	KillSwitchEnvVar   string `json:"kill_switch_env_var,omitempty"`
+	// CorrelatedSymbols maps a symbol to the instrument risk.ProposeHedge
+	// opens an offsetting position on during an extreme volatility
+	// violation, instead of market-closing the symbol's position into a
+	// flash crash. A symbol missing from this map is never hedged.
+	CorrelatedSymbols map[string]string `json:"correlated_symbols,omitempty"`
+	// AutoHedgeEnabled opens a risk.HedgeProposal automatically instead
+	// of only notifying it for manual action.
+	AutoHedgeEnabled bool `json:"auto_hedge_enabled,omitempty"`
+	// SafetyDryRun runs safety.Guard in reporting-only mode: tripped
+	// rules are logged and notified but never enforced, so a
+	// misconfigured threshold can be tuned against live data without
+	// actually pausing or reducing trading.
+	SafetyDryRun bool `json:"safety_dry_run,omitempty"`
+	// ConsecutiveLossReduceAfter and ConsecutiveLossPauseAfter configure
+	// safety.ConsecutiveLossRule. 0 disables the respective threshold.
+	ConsecutiveLossReduceAfter int `json:"consecutive_loss_reduce_after,omitempty"`
+	ConsecutiveLossPauseAfter  int `json:"consecutive_loss_pause_after,omitempty"`
+	// DisabledSafetyRules lists safety.Rule.Name() values currently
+	// disabled at runtime. Mutate through DisableSafetyRule/
+	// EnableSafetyRule rather than directly, they're safe for
+	// concurrent use.
+	DisabledSafetyRules []string `json:"disabled_safety_rules,omitempty"`
+	safetyRuleMux       sync.RWMutex
+	// SafetyRules composes the safety.Rule set built at startup via
+	// safety.BuildRules. Empty means no config-driven rules are built
+	// (the safety package's constructors can still be called directly).
+	SafetyRules []RuleConfig `json:"safety_rules,omitempty"`
+	// RequiredConfluenceIntervals are intervals that must all resolve to
+	// the same position side as TradingInterval before a signal reaches
+	// risk checking, a hard gate on top of the soft confluence score
+	// folded into Confidence, see risk.IntervalConfluence/
+	// risk.HasRequiredConfluence, applied in internal/server/analyzer.
+	// Empty disables the gate.
+	RequiredConfluenceIntervals []string `json:"required_confluence_intervals,omitempty"`
+	// RequireClosedCandle rejects a signal whose trading interval's tail
+	// candle is still forming intrabar instead of exchange-confirmed
+	// closed (models.Candlestick.Closed), so live decisions only ever
+	// score against the same final OHLC a backtest replaying historical
+	// candles would see, instead of risking a repainted indicator value
+	// on an in-progress bar. False preserves this system's original
+	// intrabar-scoring behavior. See internal/server/analyzer.process.
+	RequireClosedCandle bool `json:"require_closed_candle,omitempty"`
+	// BlacklistedSymbols is excluded from crawling, analysis, and order
+	// placement. Mutate through AddBlacklistSymbol/RemoveBlacklistSymbol
+	// rather than directly, they're safe for concurrent use.
+	BlacklistedSymbols []string `json:"blacklisted_symbols,omitempty"`
+	blacklistMux       sync.RWMutex
+	// IgnoredManualPositions are symbols with a position opened manually
+	// on the exchange that an operator has explicitly chosen to leave
+	// out of this system's risk accounting, see
+	// server.registerManualPositionCommands. Mutate through
+	// IgnoreManualPosition/UnignoreManualPosition rather than directly.
+	IgnoredManualPositions []string `json:"ignored_manual_positions,omitempty"`
+	ignoredManualMux       sync.RWMutex
+	// SimulatedTradingFeeRate is the taker fee charged on each fill by
+	// internal/services/binance/simulated, as a fraction of notional
+	// (e.g. 0.0004 = 4bps).
+	SimulatedTradingFeeRate float64 `json:"simulated_trading_fee_rate,omitempty"`
+	// SimulatedPartialFillRatio is the fraction of an order's quantity
+	// the simulated exchange fills immediately; the remainder stays
+	// open, so paper trading and backtests see the same partial-fill
+	// behavior live trading does. 1 fills completely.
+	SimulatedPartialFillRatio float64 `json:"simulated_partial_fill_ratio,omitempty"`
+	// SimulatedLatencyMillis delays every simulated exchange response,
+	// so code depending on real-world order latency (retries, timeouts)
+	// exercises the same timing paper trading and backtests.
+	SimulatedLatencyMillis int `json:"simulated_latency_millis,omitempty"`
+	// SimulatedFaultErrorRate, SimulatedFaultTimeoutRate, and
+	// SimulatedFaultMalformedRate (each 0-1) are the probabilities that
+	// a simulated exchange call fails, times out, or returns a
+	// malformed payload, see internal/libs/fault and
+	// internal/services/binance/simulated. SimulatedFaultTimeoutMillis
+	// is how long a timed-out call blocks. 0 disables the respective
+	// fault.
+	SimulatedFaultErrorRate     float64 `json:"simulated_fault_error_rate,omitempty"`
+	SimulatedFaultTimeoutRate   float64 `json:"simulated_fault_timeout_rate,omitempty"`
+	SimulatedFaultMalformedRate float64 `json:"simulated_fault_malformed_rate,omitempty"`
+	SimulatedFaultTimeoutMillis int     `json:"simulated_fault_timeout_millis,omitempty"`
+	// SimulatedOrderAwareFillsEnabled switches
+	// internal/services/binance/simulated's fill model from applying
+	// SimulatedPartialFillRatio identically to every order to one that
+	// accounts for order type: LIMIT orders only fill if the candle's
+	// range actually traded through Price, STOP_MARKET/
+	// TAKE_PROFIT_MARKET orders only fill if the candle reached
+	// StopPrice and then fill at StopPrice adjusted by
+	// SimulatedStopSlippagePercent, and every fill is additionally
+	// capped by SimulatedMaxFillVolumeRatio of the candle's traded
+	// volume. False preserves the original behavior, so existing runs
+	// aren't affected until a backtest opts in.
+	SimulatedOrderAwareFillsEnabled bool `json:"simulated_order_aware_fills_enabled,omitempty"`
+	// SimulatedStopSlippagePercent is the adverse slippage applied to a
+	// STOP_MARKET/TAKE_PROFIT_MARKET fill beyond its StopPrice, as a
+	// fraction of price (e.g. 0.001 = 0.1%). Only applied when
+	// SimulatedOrderAwareFillsEnabled.
+	SimulatedStopSlippagePercent float64 `json:"simulated_stop_slippage_percent,omitempty"`
+	// SimulatedMaxFillVolumeRatio caps how much of an order's quantity
+	// a single candle can fill, as a fraction of that candle's traded
+	// Volume, so a backtest run can't fill more size than the market
+	// actually traded. Zero leaves fills unconstrained by candle
+	// volume. Only applied when SimulatedOrderAwareFillsEnabled.
+	SimulatedMaxFillVolumeRatio float64 `json:"simulated_max_fill_volume_ratio,omitempty"`
+	// AllowedMarginAssets gates which perpetuals the crawler caches,
+	// e.g. ["USDT"] (the default), ["USDT", "USDC"] to also trade
+	// USDC-margined perpetuals, or ["USDT", "BTC"] to include
+	// coin-margined contracts. Empty falls back to USDT-only.
+	AllowedMarginAssets []string `json:"allowed_margin_assets,omitempty"`
+	// SimulatedStartingBalance is the USDT wallet balance
+	// simulated.Exchange reports from GetBalances. It has no
+	// non-USDT collateral, so it reports no other assets.
+	SimulatedStartingBalance float64 `json:"simulated_starting_balance,omitempty"`
+	// EquityTrackingIntervalSeconds is how often orderer.Orderer
+	// refreshes true account equity from Client.GetBalances, converting
+	// any non-USD collateral (e.g. BNB, BTC) via its current price, and
+	// feeds it into state.StateManager.UpdateEquity. Zero disables
+	// equity tracking, leaving MaxDrawdownPercent without a multiplier
+	// to apply.
+	EquityTrackingIntervalSeconds int `json:"equity_tracking_interval_seconds,omitempty"`
+	// CommissionRateRefreshMinutes is how often orderer.Orderer
+	// refreshes the account's actual maker/taker commission rate from
+	// Client.GetCommissionRate, per symbol, so fee-aware break-even
+	// stops and PnL accounting reflect volume/BNB fee discounts
+	// instead of assuming the default tier. Zero disables refreshing,
+	// leaving break-even/PnL to fall back on the static
+	// BreakEvenFeeBufferFraction/SimulatedTradingFeeRate assumptions.
+	CommissionRateRefreshMinutes int `json:"commission_rate_refresh_minutes,omitempty"`
+	// CandleIntegrityCheckIntervalMinutes is how often
+	// crawler.Crawler scans the market cache for gaps, duplicates, and
+	// out-of-order candles per symbol/interval, repairing whatever it
+	// finds via the same Client.GetCandlesticks REST backfill StartRetry
+	// uses. Zero disables the check, leaving a stalled websocket feed's
+	// cache corruption to go unnoticed until it affects a decision.
+	CandleIntegrityCheckIntervalMinutes int `json:"candle_integrity_check_interval_minutes,omitempty"`
+	// ExchangeMaintenanceCheckIntervalSeconds is how often
+	// crawler.Crawler polls Binance.GetSystemStatus for an exchange-wide
+	// maintenance window, see cache.Exchange.SetMaintenance and
+	// safety.TradingStatusRule. Zero disables the check, leaving trading
+	// to only notice a maintenance window once orders start failing.
+	ExchangeMaintenanceCheckIntervalSeconds int `json:"exchange_maintenance_check_interval_seconds,omitempty"`
+	// MaxLeverageByStrategy caps the leverage GetPreferLeverage may
+	// recommend, per strategy, see MaxLeverageFor. A strategy missing
+	// from this map falls back to DefaultMaxLeverage. 0 means uncapped
+	// for that strategy.
+	MaxLeverageByStrategy map[TradingStrategy]int `json:"max_leverage_by_strategy,omitempty"`
+	// DefaultMaxLeverage is the leverage cap used for a strategy missing
+	// from MaxLeverageByStrategy. 0 disables the cap entirely.
+	DefaultMaxLeverage int `json:"default_max_leverage,omitempty"`
+	// MajorSymbols are the symbols MaxLeverageFor treats as the "majors"
+	// tier (e.g. BTCUSDT, ETHUSDT), capped by MaxLeverageMajors instead
+	// of MaxLeverageAlts. A symbol missing from this list is an "alt".
+	MajorSymbols []string `json:"major_symbols,omitempty"`
+	// MaxLeverageMajors and MaxLeverageAlts cap the leverage
+	// GetPreferLeverage may recommend, per symbol tier, see
+	// MaxLeverageFor. 0 means uncapped for that tier.
+	MaxLeverageMajors int `json:"max_leverage_majors,omitempty"`
+	MaxLeverageAlts   int `json:"max_leverage_alts,omitempty"`
+	// NewsFeedURL is the RSS feed crawler.Crawler polls for headlines
+	// that should pause trading on an affected held symbol, see
+	// NewsKeywords and safety.NewsKillSwitchRule. Empty disables the
+	// monitor entirely.
+	NewsFeedURL string `json:"news_feed_url,omitempty"`
+	// NewsKeywords are the case-insensitive substrings (e.g. "hack",
+	// "delisting", "SEC") a feed item's title or description is
+	// checked against. An item matching none of them is ignored.
+	NewsKeywords []string `json:"news_keywords,omitempty"`
+	// NewsCheckIntervalSeconds is how often crawler.Crawler polls
+	// NewsFeedURL. Zero disables the check even if NewsFeedURL is set.
+	NewsCheckIntervalSeconds int `json:"news_check_interval_seconds,omitempty"`
+	// Profiles are named bundles of risk knobs SetProfile can switch to
+	// at runtime, e.g. a "defensive" profile scheduled for
+	// weekends/news and an "aggressive" one for high-conviction
+	// periods. Mutate through SetProfile rather than directly.
+	Profiles      map[string]*ProfileOverrides `json:"profiles,omitempty"`
+	activeProfile string
+	profileMux    sync.RWMutex
+	// ReferenceProfile names the entry in Profiles holding the most
+	// recent best-performing configuration from an external
+	// backtest/optimization run over the trailing period, refreshed via
+	// SetReferenceProfile whenever one completes. report.Report compares
+	// it against the live values SetProfile manages, see
+	// Settings.ParameterDrift. Empty disables the comparison.
+	ReferenceProfile string `json:"reference_profile,omitempty"`
+	// ParameterDriftThresholdPercent is how far a live value may diverge
+	// from ReferenceProfile's, in percent, before ParameterDrift reports
+	// it. 0 disables the comparison even if ReferenceProfile is set.
+	ParameterDriftThresholdPercent float64 `json:"parameter_drift_threshold_percent,omitempty"`
+}
+
+// ProfileOverrides is a named settings profile's risk knobs, applied
+// wholesale over the matching fields on Settings by SetProfile.
+type ProfileOverrides struct {
+	TradingCost              float64 `json:"trading_cost,omitempty"`
+	MaxPositionsGlobalHourly int     `json:"max_positions_global_hourly,omitempty"`
+	MaxPositionsGlobalDaily  int     `json:"max_positions_global_daily,omitempty"`
+	MaxDrawdownPercent       float64 `json:"max_drawdown_percent,omitempty"`
+}
+
+// IsBlacklisted reports whether symbol is currently blacklisted.
+func (s *Settings) IsBlacklisted(symbol string) bool {
+	s.blacklistMux.RLock()
+	defer s.blacklistMux.RUnlock()
+
+	for _, blacklisted := range s.BlacklistedSymbols {
+		if blacklisted == symbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddBlacklistSymbol blacklists symbol, if it isn't already.
+func (s *Settings) AddBlacklistSymbol(symbol string) {
+	if s.IsBlacklisted(symbol) {
+		return
+	}
+
+	s.blacklistMux.Lock()
+	defer s.blacklistMux.Unlock()
+
+	s.BlacklistedSymbols = append(s.BlacklistedSymbols, symbol)
+}
+
+// IsMarginAssetAllowed reports whether the crawler should cache a
+// symbol margined in marginAsset, per AllowedMarginAssets. An empty
+// AllowedMarginAssets allows only USDT, preserving this repo's
+// original USDT-only behavior.
+func (s *Settings) IsMarginAssetAllowed(marginAsset string) bool {
+	if len(s.AllowedMarginAssets) == 0 {
+		return marginAsset == "USDT"
+	}
+
+	for _, allowed := range s.AllowedMarginAssets {
+		if allowed == marginAsset {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveBlacklistSymbol removes symbol from the blacklist, if present.
+func (s *Settings) RemoveBlacklistSymbol(symbol string) {
+	s.blacklistMux.Lock()
+	defer s.blacklistMux.Unlock()
+
+	for i, blacklisted := range s.BlacklistedSymbols {
+		if blacklisted == symbol {
+			s.BlacklistedSymbols = append(s.BlacklistedSymbols[:i], s.BlacklistedSymbols[i+1:]...)
+			return
+		}
+	}
+}
+
+// IsManualPositionIgnored reports whether symbol's manually opened
+// position has been explicitly left out of risk accounting.
+func (s *Settings) IsManualPositionIgnored(symbol string) bool {
+	s.ignoredManualMux.RLock()
+	defer s.ignoredManualMux.RUnlock()
+
+	for _, ignored := range s.IgnoredManualPositions {
+		if ignored == symbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IgnoreManualPosition marks symbol's manually opened position as
+// deliberately left out of risk accounting, if it isn't already.
+func (s *Settings) IgnoreManualPosition(symbol string) {
+	if s.IsManualPositionIgnored(symbol) {
+		return
+	}
+
+	s.ignoredManualMux.Lock()
+	defer s.ignoredManualMux.Unlock()
+
+	s.IgnoredManualPositions = append(s.IgnoredManualPositions, symbol)
+}
+
+// UnignoreManualPosition removes symbol from IgnoredManualPositions, if
+// present, e.g. once it's been adopted instead.
+func (s *Settings) UnignoreManualPosition(symbol string) {
+	s.ignoredManualMux.Lock()
+	defer s.ignoredManualMux.Unlock()
+
+	for i, ignored := range s.IgnoredManualPositions {
+		if ignored == symbol {
+			s.IgnoredManualPositions = append(s.IgnoredManualPositions[:i], s.IgnoredManualPositions[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetProfile switches the account to the named entry in Profiles,
+// overwriting TradingCost, MaxPositionsGlobalHourly,
+// MaxPositionsGlobalDaily, and MaxDrawdownPercent with its overrides.
+// Returns an error if name isn't in Profiles.
+func (s *Settings) SetProfile(name string) error {
+	s.profileMux.Lock()
+	defer s.profileMux.Unlock()
+
+	overrides, ok := s.Profiles[name]
+	if !ok {
+		return fmt.Errorf("settings: unknown profile %q", name)
+	}
+
+	s.TradingCost = overrides.TradingCost
+	s.MaxPositionsGlobalHourly = overrides.MaxPositionsGlobalHourly
+	s.MaxPositionsGlobalDaily = overrides.MaxPositionsGlobalDaily
+	s.MaxDrawdownPercent = overrides.MaxDrawdownPercent
+	s.activeProfile = name
+
+	return nil
+}
+
+// ActiveProfile returns the name of the settings profile SetProfile
+// last switched to, or "" if it has never been called, so a decision
+// can record which profile was active when it was made.
+func (s *Settings) ActiveProfile() string {
+	s.profileMux.RLock()
+	defer s.profileMux.RUnlock()
+
+	return s.activeProfile
+}
+
+// SetReferenceProfile sets ReferenceProfile to name, the entry in
+// Profiles report.Report's weekly parameter drift check compares live
+// values against, for an external backtest/optimization pipeline (or
+// an operator, via /reference_profile) to point at its latest result.
+// Returns an error if name isn't in Profiles.
+func (s *Settings) SetReferenceProfile(name string) error {
+	s.profileMux.Lock()
+	defer s.profileMux.Unlock()
+
+	if _, ok := s.Profiles[name]; !ok {
+		return fmt.Errorf("settings: unknown profile %q", name)
+	}
+
+	s.ReferenceProfile = name
+	return nil
+}
+
+// ParameterDrift compares the live values SetProfile manages
+// (TradingCost, MaxPositionsGlobalHourly, MaxPositionsGlobalDaily,
+// MaxDrawdownPercent) against ReferenceProfile's, and returns the
+// percent drift for every field that diverges by more than
+// ParameterDriftThresholdPercent, keyed by field name. It returns nil
+// if ReferenceProfile is unset, unknown, or ParameterDriftThresholdPercent
+// <= 0 — this repo has no backtest optimizer of its own, so
+// ReferenceProfile is expected to be kept current by whatever external
+// process runs the trailing-period optimization.
+func (s *Settings) ParameterDrift() map[string]float64 {
+	if s.ReferenceProfile == "" || s.ParameterDriftThresholdPercent <= 0 {
+		return nil
+	}
+
+	s.profileMux.RLock()
+	reference, ok := s.Profiles[s.ReferenceProfile]
+	s.profileMux.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	fields := map[string][2]float64{
+		"trading_cost":                {s.TradingCost, reference.TradingCost},
+		"max_positions_global_hourly": {float64(s.MaxPositionsGlobalHourly), float64(reference.MaxPositionsGlobalHourly)},
+		"max_positions_global_daily":  {float64(s.MaxPositionsGlobalDaily), float64(reference.MaxPositionsGlobalDaily)},
+		"max_drawdown_percent":        {s.MaxDrawdownPercent, reference.MaxDrawdownPercent},
+	}
+
+	drift := make(map[string]float64)
+
+	for name, values := range fields {
+		live, ref := values[0], values[1]
+		if ref == 0 {
+			continue
+		}
+
+		percent := math.Abs(live-ref) / math.Abs(ref) * 100
+		if percent > s.ParameterDriftThresholdPercent {
+			drift[name] = percent
+		}
+	}
+
+	return drift
+}
+
+// IsSafetyRuleDisabled reports whether the safety.Rule named ruleName is
+// currently disabled at runtime.
+func (s *Settings) IsSafetyRuleDisabled(ruleName string) bool {
+	s.safetyRuleMux.RLock()
+	defer s.safetyRuleMux.RUnlock()
+
+	for _, disabled := range s.DisabledSafetyRules {
+		if disabled == ruleName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DisableSafetyRule disables the safety.Rule named ruleName, if it
+// isn't already.
+func (s *Settings) DisableSafetyRule(ruleName string) {
+	if s.IsSafetyRuleDisabled(ruleName) {
+		return
+	}
+
+	s.safetyRuleMux.Lock()
+	defer s.safetyRuleMux.Unlock()
+
+	s.DisabledSafetyRules = append(s.DisabledSafetyRules, ruleName)
+}
+
+// EnableSafetyRule re-enables the safety.Rule named ruleName, if it was
+// disabled.
+func (s *Settings) EnableSafetyRule(ruleName string) {
+	s.safetyRuleMux.Lock()
+	defer s.safetyRuleMux.Unlock()
+
+	for i, disabled := range s.DisabledSafetyRules {
+		if disabled == ruleName {
+			s.DisabledSafetyRules = append(s.DisabledSafetyRules[:i], s.DisabledSafetyRules[i+1:]...)
+			return
+		}
+	}
+}
+
+// IntervalFor returns the candle interval configured for strategy,
+// falling back to TradingInterval when strategy has no override.
+func (s *Settings) IntervalFor(strategy TradingStrategy) string {
+	if interval, ok := s.TradingIntervals[strategy]; ok && interval != "" {
+		return interval
+	}
+	return s.TradingInterval
+}
+
+// MaxHoldingPeriodFor returns the holding period limit for strategy, in
+// minutes, falling back to DefaultMaxHoldingPeriodMinutes. 0 means the
+// check is disabled for strategy.
+func (s *Settings) MaxHoldingPeriodFor(strategy TradingStrategy) int {
+	if minutes, ok := s.MaxHoldingPeriodMinutes[strategy]; ok && minutes > 0 {
+		return minutes
+	}
+	return s.DefaultMaxHoldingPeriodMinutes
+}
+
+// StopDistanceATRMultipleFor returns the ATR-multiple stop-distance cap
+// for strategy, falling back to MaxStopDistanceATRMultiple. <= 0 means
+// the cap is disabled for strategy.
+func (s *Settings) StopDistanceATRMultipleFor(strategy TradingStrategy) float64 {
+	if multiple, ok := s.StopDistanceATRMultipleByStrategy[strategy]; ok && multiple > 0 {
+		return multiple
+	}
+	return s.MaxStopDistanceATRMultiple
+}
+
+// MinWarmupCandlesFor returns the minimum number of closed candles an
+// interval must have cached before the analyzer computes indicators
+// for strategy, falling back to DefaultMinWarmupCandles. 0 means the
+// check is disabled for strategy.
+func (s *Settings) MinWarmupCandlesFor(strategy TradingStrategy) int {
+	if min, ok := s.MinWarmupCandles[strategy]; ok && min > 0 {
+		return min
+	}
+	return s.DefaultMinWarmupCandles
 }
 
 func NewDefaultSettings() *Settings {
 	return &Settings{
-		SignalDisabled:         false,
-		TradingEnabled:         true,
-		TradingCost:            10, // USD
-		TradingInterval:        "15m",
-		TradingStrategy:        TradingStrategyInstantNoodles,
+		SignalDisabled:  false,
+		TradingEnabled:  true,
+		TradingCost:     10, // USD
+		TradingInterval: "15m",
+		TradingStrategy: TradingStrategyInstantNoodles,
+		TradingIntervals: map[TradingStrategy]string{
+			TradingStrategyInstantNoodles:      "15m",
+			TradingStrategyDollarCostAveraging: "1h",
+			TradingStrategyFundingWindowScalp:  "5m",
+		},
 		MaxPositionsDaily:      300,
 		MaxPositionsPerTime:    3,
 		PreferLeverageBrackets: []int{20, 10},
+		DefaultMaxLeverage:     10,
+		MajorSymbols:           []string{"BTCUSDT", "ETHUSDT"},
+		MaxLeverageMajors:      10,
+		MaxLeverageAlts:        5,
 		LongPNL: &PNL{
 			GainPricePercent: 1.2,
 			LossPricePercent: 0.8,
@@ -58,6 +783,55 @@ func NewDefaultSettings() *Settings {
 			DesiredProfit:    1.2,
 			DesiredLoss:      -10, // TODO
 		},
+		PositionSizingMode:                      0, // confidence-based
+		EngineVersion:                           "v1",
+		AltEngineVersion:                        "",
+		AltEngineRolloutPercent:                 0,
+		MaxPositionsPerSymbolHourly:             2,
+		MaxPositionsPerSymbolDaily:              6,
+		MaxPositionsGlobalHourly:                10,
+		MaxPositionsGlobalDaily:                 50,
+		MaxDrawdownPercent:                      25,
+		FundingAvoidanceMinutes:                 10,
+		MinSymbolListingDays:                    7,
+		ConsecutiveLossReduceAfter:              3,
+		ConsecutiveLossPauseAfter:               5,
+		MaxSpreadBps:                            15,
+		MinBookSizeRatio:                        1.5,
+		NotificationRateLimitMinutes:            10,
+		NotificationBypassConfidence:            0.9,
+		DigestIntervalMinutes:                   15,
+		DigestConfidenceThreshold:               0.5,
+		ReportOutputDir:                         "./reports",
+		BenchmarkSymbols:                        []string{"BTCUSDT", "ETHUSDT"},
+		HeartbeatIntervalSeconds:                60,
+		ExitEvaluationIntervalSeconds:           60,
+		BreakEvenRMultiple:                      1,
+		BreakEvenFeeBufferFraction:              0.0008,
+		KillSwitchFilePath:                      "./KILLSWITCH",
+		KillSwitchEnvVar:                        "FUTURES_TRADING_KILL_SWITCH",
+		SimulatedTradingFeeRate:                 0.0004,
+		SimulatedPartialFillRatio:               1,
+		AllowedMarginAssets:                     []string{"USDT"},
+		SimulatedStartingBalance:                10000,
+		EquityTrackingIntervalSeconds:           60,
+		CommissionRateRefreshMinutes:            360,
+		CandleIntegrityCheckIntervalMinutes:     30,
+		ExchangeMaintenanceCheckIntervalSeconds: 60,
+		Profiles: map[string]*ProfileOverrides{
+			"aggressive": {
+				TradingCost:              20,
+				MaxPositionsGlobalHourly: 20,
+				MaxPositionsGlobalDaily:  100,
+				MaxDrawdownPercent:       35,
+			},
+			"defensive": {
+				TradingCost:              5,
+				MaxPositionsGlobalHourly: 3,
+				MaxPositionsGlobalDaily:  10,
+				MaxDrawdownPercent:       15,
+			},
+		},
 	}
 }
 
@@ -73,3 +847,42 @@ func (s *Settings) GetPreferLeverage(leverageBrackets []*binance.LeverageBracket
 	}
 	return 5
 }
+
+// IsMajorSymbol reports whether symbol is in the "majors" tier, per
+// MajorSymbols.
+func (s *Settings) IsMajorSymbol(symbol string) bool {
+	for _, major := range s.MajorSymbols {
+		if major == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxLeverageFor caps leverage against the hard limits configured for
+// strategy (MaxLeverageByStrategy/DefaultMaxLeverage) and symbol's tier
+// (MaxLeverageMajors/MaxLeverageAlts, per IsMajorSymbol), applied after
+// GetPreferLeverage has made its recommendation. It returns the
+// tightest of the two caps that apply, or leverage unchanged if neither
+// cap is set.
+func (s *Settings) MaxLeverageFor(strategy TradingStrategy, symbol string, leverage int) int {
+	cap := leverage
+
+	strategyCap, ok := s.MaxLeverageByStrategy[strategy]
+	if !ok {
+		strategyCap = s.DefaultMaxLeverage
+	}
+	if strategyCap > 0 && strategyCap < cap {
+		cap = strategyCap
+	}
+
+	tierCap := s.MaxLeverageAlts
+	if s.IsMajorSymbol(symbol) {
+		tierCap = s.MaxLeverageMajors
+	}
+	if tierCap > 0 && tierCap < cap {
+		cap = tierCap
+	}
+
+	return cap
+}
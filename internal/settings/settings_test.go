@@ -0,0 +1,107 @@
+package settings
+
+import "testing"
+
+func TestMaxLeverageFor(t *testing.T) {
+	s := &Settings{
+		MaxLeverageByStrategy: map[TradingStrategy]int{
+			TradingStrategyInstantNoodles: 8,
+		},
+		DefaultMaxLeverage: 10,
+		MajorSymbols:       []string{"BTCUSDT"},
+		MaxLeverageMajors:  10,
+		MaxLeverageAlts:    5,
+	}
+
+	cases := []struct {
+		name     string
+		strategy TradingStrategy
+		symbol   string
+		leverage int
+		want     int
+	}{
+		{"strategy cap binds for major", TradingStrategyInstantNoodles, "BTCUSDT", 20, 8},
+		{"tier cap binds for alt", TradingStrategyDollarCostAveraging, "DOGEUSDT", 20, 5},
+		{"recommendation under both caps passes through", TradingStrategyInstantNoodles, "BTCUSDT", 3, 3},
+		{"missing strategy falls back to default cap", TradingStrategyDollarCostAveraging, "BTCUSDT", 20, 10},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := s.MaxLeverageFor(c.strategy, c.symbol, c.leverage); got != c.want {
+				t.Errorf("MaxLeverageFor(%v, %q, %d) = %d, want %d", c.strategy, c.symbol, c.leverage, got, c.want)
+			}
+		})
+	}
+}
+
+func TestMaxLeverageForUncapped(t *testing.T) {
+	s := NewDefaultSettings()
+	s.DefaultMaxLeverage = 0
+	s.MaxLeverageMajors = 0
+	s.MaxLeverageAlts = 0
+
+	if got := s.MaxLeverageFor(TradingStrategyInstantNoodles, "BTCUSDT", 20); got != 20 {
+		t.Errorf("expected uncapped leverage to pass through unchanged, got %d", got)
+	}
+}
+
+func TestParameterDrift(t *testing.T) {
+	s := &Settings{
+		TradingCost:                    100,
+		MaxPositionsGlobalHourly:       5,
+		MaxPositionsGlobalDaily:        20,
+		MaxDrawdownPercent:             10,
+		ReferenceProfile:               "optimized",
+		ParameterDriftThresholdPercent: 10,
+		Profiles: map[string]*ProfileOverrides{
+			"optimized": {
+				TradingCost:              80,
+				MaxPositionsGlobalHourly: 5,
+				MaxPositionsGlobalDaily:  20,
+				MaxDrawdownPercent:       10,
+			},
+		},
+	}
+
+	drift := s.ParameterDrift()
+
+	if len(drift) != 1 {
+		t.Fatalf("expected exactly 1 field drifted past threshold, got %v", drift)
+	}
+
+	if _, ok := drift["trading_cost"]; !ok {
+		t.Errorf("expected trading_cost to have drifted, got %v", drift)
+	}
+}
+
+func TestParameterDriftDisabled(t *testing.T) {
+	s := NewDefaultSettings()
+	s.Profiles = map[string]*ProfileOverrides{"optimized": {TradingCost: 1}}
+	s.TradingCost = 100
+
+	if drift := s.ParameterDrift(); drift != nil {
+		t.Errorf("expected nil drift with ReferenceProfile unset, got %v", drift)
+	}
+
+	s.ReferenceProfile = "optimized"
+	if drift := s.ParameterDrift(); drift != nil {
+		t.Errorf("expected nil drift with ParameterDriftThresholdPercent unset, got %v", drift)
+	}
+}
+
+func TestSetReferenceProfile(t *testing.T) {
+	s := &Settings{Profiles: map[string]*ProfileOverrides{"optimized": {}}}
+
+	if err := s.SetReferenceProfile("optimized"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s.ReferenceProfile != "optimized" {
+		t.Errorf("expected ReferenceProfile to be set, got %q", s.ReferenceProfile)
+	}
+
+	if err := s.SetReferenceProfile("missing"); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+}
@@ -0,0 +1,38 @@
+package state
+
+import "github.com/anvh2/futures-trading/internal/risk"
+
+// UpdateEquity records a new equity observation against the persisted
+// equity curve and returns the deleverage multiplier decision sizing
+// should apply, given maxDrawdownPercent (see risk.EquityCurve). Like
+// RecordOrderEvent, it replaces rather than mutates the curve and its
+// enclosing TradingState, see StateManager.
+func (m *StateManager) UpdateEquity(equity float64, maxDrawdownPercent float64) float64 {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	curve := &risk.EquityCurve{}
+	if m.state.Equity != nil {
+		*curve = *m.state.Equity
+	}
+	curve.Update(equity)
+
+	m.state = &TradingState{
+		SchemaVersion: m.state.SchemaVersion,
+		Positions:     m.state.Positions,
+		Equity:        curve,
+	}
+	m.version++
+
+	return curve.SizeMultiplier(maxDrawdownPercent)
+}
+
+// Equity returns the current equity curve, for a caller (e.g. a
+// safety.Rule) that needs just this field instead of the whole
+// TradingState.
+func (m *StateManager) Equity() *risk.EquityCurve {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	return m.state.Equity
+}
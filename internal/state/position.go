@@ -0,0 +1,294 @@
+package state
+
+import "time"
+
+// PositionSourceManual tags a PositionRecord adopted from a position
+// opened manually on the exchange, see StateManager.AdoptPosition. An
+// empty Source means the position was opened by this system's own
+// orderer pipeline.
+const PositionSourceManual = "manual"
+
+// OrderEventType enumerates the lifecycle events an exchange order can
+// go through, as reported by the orderer.
+type OrderEventType string
+
+const (
+	OrderEventCreated         OrderEventType = "created"
+	OrderEventAmended         OrderEventType = "amended"
+	OrderEventPartiallyFilled OrderEventType = "partially_filled"
+	OrderEventFilled          OrderEventType = "filled"
+	OrderEventCanceled        OrderEventType = "canceled"
+)
+
+// OrderEvent is a single lifecycle transition recorded against an order
+// belonging to a PositionRecord, so post-trade review can show exactly
+// how an entry and its exits executed.
+type OrderEvent struct {
+	OrderId   string         `json:"order_id,omitempty"`
+	Type      OrderEventType `json:"type,omitempty"`
+	Price     string         `json:"price,omitempty"`
+	Quantity  string         `json:"quantity,omitempty"`
+	Timestamp int64          `json:"timestamp,omitempty"`
+}
+
+// PositionRecord is the bot's own bookkeeping for a symbol's open
+// position, independent of the exchange's position snapshot, so the
+// full order event history survives restarts.
+type PositionRecord struct {
+	Symbol          string        `json:"symbol,omitempty"`
+	Side            string        `json:"side,omitempty"`
+	Events          []*OrderEvent `json:"events,omitempty"`
+	OriginalQty     float64       `json:"original_qty,omitempty"`
+	FilledQty       float64       `json:"filled_qty,omitempty"`
+	PartiallyFilled bool          `json:"partially_filled,omitempty"`
+	// EntryPrice and StopPrice/StopOrderId are the position's initial
+	// entry fill price and resting stop-loss order, used by
+	// orderer.applyBreakEvenStop to know the position's R (entry-to-stop
+	// distance) and which order to replace once it's moved to
+	// break-even. Empty when the strategy didn't place a stop-loss order.
+	EntryPrice       string `json:"entry_price,omitempty"`
+	StopPrice        string `json:"stop_price,omitempty"`
+	StopOrderId      string `json:"stop_order_id,omitempty"`
+	BreakEvenApplied bool   `json:"break_even_applied,omitempty"`
+	// TakeProfitPrice is the position's resting take-profit order price,
+	// if the strategy placed one, recorded purely for post-trade review
+	// (e.g. orderer's trade-completed chart snapshot) since nothing
+	// manages it the way applyBreakEvenStop manages StopPrice.
+	TakeProfitPrice string `json:"take_profit_price,omitempty"`
+	// Profile is the settings.Settings.ActiveProfile in effect when the
+	// position's first order event was recorded, so post-trade review
+	// can tell which risk profile a decision was made under.
+	Profile string `json:"profile,omitempty"`
+	// Source is PositionSourceManual for a position adopted from one
+	// opened manually on the exchange, empty otherwise. See
+	// StateManager.AdoptPosition.
+	Source string `json:"source,omitempty"`
+}
+
+// AddEvent appends an order event to the position record's history.
+func (p *PositionRecord) AddEvent(event *OrderEvent) {
+	p.Events = append(p.Events, event)
+}
+
+// OpenedAt returns the timestamp of the position's first recorded
+// event, i.e. when it was entered, or the zero time if it has none.
+func (p *PositionRecord) OpenedAt() time.Time {
+	if len(p.Events) == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(p.Events[0].Timestamp)
+}
+
+// RecordOrderEvent appends event to the position for symbol, creating
+// the position record if this is its first known event, with profile
+// recording the settings.Settings.ActiveProfile in effect when the
+// decision was made. It replaces the position and its enclosing
+// TradingState with copies rather than mutating them in place, so a
+// *TradingState handed out by GetState or Snapshot stays an immutable
+// view of the version it was read at.
+func (m *StateManager) RecordOrderEvent(symbol, side, profile string, event *OrderEvent) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	position := &PositionRecord{Symbol: symbol, Side: side, Profile: profile}
+	if existing, ok := m.state.Positions[symbol]; ok {
+		*position = *existing
+		position.Events = append([]*OrderEvent{}, existing.Events...)
+	}
+	position.AddEvent(event)
+
+	positions := make(map[string]*PositionRecord, len(m.state.Positions))
+	for k, v := range m.state.Positions {
+		positions[k] = v
+	}
+	positions[symbol] = position
+
+	m.state = &TradingState{
+		SchemaVersion: m.state.SchemaVersion,
+		Positions:     positions,
+		Equity:        m.state.Equity,
+	}
+	m.version++
+}
+
+// SetLevels records symbol's entry price, initial stop-loss
+// price/order id, take-profit price (empty if the strategy didn't place
+// one), and the quantity that actually filled on the entry order,
+// creating the position record if this is its first known event. Safe
+// to call before or after RecordOrderEvent. filledQty also seeds
+// OriginalQty, so TrimPosition/rebalance have a tracked size to work
+// from the same way they already do for a position AdoptPosition
+// brought under management.
+func (m *StateManager) SetLevels(symbol, entryPrice, stopPrice, stopOrderId, takeProfitPrice string, filledQty float64) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	position := &PositionRecord{Symbol: symbol}
+	if existing, ok := m.state.Positions[symbol]; ok {
+		*position = *existing
+		position.Events = append([]*OrderEvent{}, existing.Events...)
+	}
+	position.EntryPrice = entryPrice
+	position.StopPrice = stopPrice
+	position.StopOrderId = stopOrderId
+	position.TakeProfitPrice = takeProfitPrice
+	position.OriginalQty = filledQty
+	position.FilledQty = filledQty
+
+	positions := make(map[string]*PositionRecord, len(m.state.Positions))
+	for k, v := range m.state.Positions {
+		positions[k] = v
+	}
+	positions[symbol] = position
+
+	m.state = &TradingState{
+		SchemaVersion: m.state.SchemaVersion,
+		Positions:     positions,
+		Equity:        m.state.Equity,
+	}
+	m.version++
+}
+
+// AdoptPosition brings a position opened manually on the exchange under
+// this system's management: it records the same entry/stop/take-profit
+// levels SetLevels would for a position this system opened itself, plus
+// side and quantity read from the exchange's position snapshot since
+// there's no OpenOrders event history to derive them from, and tags the
+// record PositionSourceManual so post-trade review and risk accounting
+// can tell it apart from a position this system entered itself. It
+// replaces any existing record for symbol outright, since a position
+// worth adopting by definition isn't one this system already tracks.
+func (m *StateManager) AdoptPosition(symbol, side string, quantity float64, entryPrice, stopPrice, stopOrderId, takeProfitPrice string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	position := &PositionRecord{
+		Symbol:          symbol,
+		Side:            side,
+		Source:          PositionSourceManual,
+		OriginalQty:     quantity,
+		FilledQty:       quantity,
+		EntryPrice:      entryPrice,
+		StopPrice:       stopPrice,
+		StopOrderId:     stopOrderId,
+		TakeProfitPrice: takeProfitPrice,
+	}
+
+	positions := make(map[string]*PositionRecord, len(m.state.Positions))
+	for k, v := range m.state.Positions {
+		positions[k] = v
+	}
+	positions[symbol] = position
+
+	m.state = &TradingState{
+		SchemaVersion: m.state.SchemaVersion,
+		Positions:     positions,
+		Equity:        m.state.Equity,
+	}
+	m.version++
+}
+
+// MoveStop records that symbol's stop-loss has been replaced by a new
+// order at stopPrice, and marks BreakEvenApplied so
+// orderer.applyBreakEvenStop only moves the stop once per position.
+func (m *StateManager) MoveStop(symbol, stopPrice, stopOrderId string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	existing, ok := m.state.Positions[symbol]
+	if !ok {
+		return
+	}
+
+	position := &PositionRecord{}
+	*position = *existing
+	position.Events = append([]*OrderEvent{}, existing.Events...)
+	position.StopPrice = stopPrice
+	position.StopOrderId = stopOrderId
+	position.BreakEvenApplied = true
+
+	positions := make(map[string]*PositionRecord, len(m.state.Positions))
+	for k, v := range m.state.Positions {
+		positions[k] = v
+	}
+	positions[symbol] = position
+
+	m.state = &TradingState{
+		SchemaVersion: m.state.SchemaVersion,
+		Positions:     positions,
+		Equity:        m.state.Equity,
+	}
+	m.version++
+}
+
+// TrimPosition reduces symbol's tracked filled quantity by quantity,
+// the position's own bookkeeping counterpart to the reduce-only order
+// orderer.trimPosition submits to cut down an oversized winner. A no-op
+// if symbol isn't tracked. FilledQty never drops below zero.
+func (m *StateManager) TrimPosition(symbol string, quantity float64) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	existing, ok := m.state.Positions[symbol]
+	if !ok {
+		return
+	}
+
+	position := &PositionRecord{}
+	*position = *existing
+	position.Events = append([]*OrderEvent{}, existing.Events...)
+	position.FilledQty -= quantity
+	if position.FilledQty < 0 {
+		position.FilledQty = 0
+	}
+
+	positions := make(map[string]*PositionRecord, len(m.state.Positions))
+	for k, v := range m.state.Positions {
+		positions[k] = v
+	}
+	positions[symbol] = position
+
+	m.state = &TradingState{
+		SchemaVersion: m.state.SchemaVersion,
+		Positions:     positions,
+		Equity:        m.state.Equity,
+	}
+	m.version++
+}
+
+// Position returns the position record for symbol, if any is tracked.
+func (m *StateManager) Position(symbol string) (*PositionRecord, bool) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	position, ok := m.state.Positions[symbol]
+	return position, ok
+}
+
+// ClosePosition removes symbol's position record, e.g. once its closing
+// order has been submitted, so later mutators/readers no longer see it
+// as held. It replaces rather than mutates the enclosing TradingState,
+// per the StateManager doc comment.
+func (m *StateManager) ClosePosition(symbol string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if _, ok := m.state.Positions[symbol]; !ok {
+		return
+	}
+
+	positions := make(map[string]*PositionRecord, len(m.state.Positions))
+	for k, v := range m.state.Positions {
+		if k == symbol {
+			continue
+		}
+		positions[k] = v
+	}
+
+	m.state = &TradingState{
+		SchemaVersion: m.state.SchemaVersion,
+		Positions:     positions,
+		Equity:        m.state.Equity,
+	}
+	m.version++
+}
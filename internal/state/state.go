@@ -0,0 +1,165 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/anvh2/futures-trading/internal/libs/simpledb"
+	"github.com/anvh2/futures-trading/internal/logger"
+	"github.com/anvh2/futures-trading/internal/risk"
+	"go.uber.org/zap"
+)
+
+// CurrentSchemaVersion is bumped whenever TradingState gains or changes a
+// field in a way that requires a migration to run against older
+// persisted state.
+const CurrentSchemaVersion = 1
+
+// TradingState is the subset of bot state that survives a restart: open
+// position bookkeeping keyed by symbol, plus enough metadata to migrate
+// older persisted copies forward.
+type TradingState struct {
+	SchemaVersion int                        `json:"schema_version"`
+	Positions     map[string]*PositionRecord `json:"positions,omitempty"`
+	// Equity is the account's peak/current equity curve, used by
+	// risk.EquityCurve to auto-deleverage position sizing through a
+	// drawdown and restore it on recovery. Zero value is a fresh curve.
+	Equity *risk.EquityCurve `json:"equity,omitempty"`
+}
+
+// Migration upgrades a TradingState from one schema version to the next.
+type Migration func(*TradingState) error
+
+// migrations is keyed by the schema version a migration upgrades FROM.
+var migrations = map[int]Migration{}
+
+// StateManager owns the in-memory TradingState and persists it through a
+// simpledb-backed file, applying any pending migrations on load.
+//
+// Mutators (RecordOrderEvent, UpdateEquity, Load, Restore) never modify
+// the fields of an already-published TradingState/PositionRecord in
+// place; they build a replacement and swap m.state under mux.Lock
+// instead, bumping version. That makes every *TradingState handed out
+// by GetState/Snapshot an immutable view frozen at the version it was
+// read at, so a caller like a safety.Rule can hold onto one across a
+// whole evaluation without re-locking or racing a concurrent writer.
+type StateManager struct {
+	logger  *logger.Logger
+	db      *simpledb.DB
+	mux     *sync.RWMutex
+	state   *TradingState
+	version int64
+}
+
+// New returns a StateManager backed by the file at path. Load must be
+// called before the state is usable if an existing file should be
+// restored.
+func New(logger *logger.Logger, path string) *StateManager {
+	return &StateManager{
+		logger: logger,
+		db:     simpledb.Open(path),
+		mux:    &sync.RWMutex{},
+		state: &TradingState{
+			SchemaVersion: CurrentSchemaVersion,
+			Positions:     make(map[string]*PositionRecord),
+			Equity:        &risk.EquityCurve{},
+		},
+	}
+}
+
+// Load restores the persisted state and migrates it to CurrentSchemaVersion.
+func (m *StateManager) Load() error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	state := &TradingState{}
+	if err := m.db.Load(state); err != nil {
+		return err
+	}
+
+	if err := migrate(state); err != nil {
+		m.logger.Error("[StateManager] failed to migrate state", zap.Error(err))
+		return err
+	}
+
+	m.state = state
+	m.version++
+	return nil
+}
+
+// Save persists the current state to disk.
+func (m *StateManager) Save() error {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	return m.db.Save(m.state)
+}
+
+// Backup copies the persisted state file to a timestamped sibling, per
+// simpledb.DB.Backup.
+func (m *StateManager) Backup(cfg *simpledb.BackupConfig) error {
+	return m.db.Backup(cfg)
+}
+
+// GetState returns the current immutable TradingState, see
+// StateManager. Prefer Position/Equity for a single field, or Snapshot
+// to also observe the version it was read at.
+func (m *StateManager) GetState() *TradingState {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	return m.state
+}
+
+// Snapshot returns the current immutable TradingState together with
+// its version, so a caller can cheaply detect whether state has
+// changed since a previous read (version unchanged) without
+// deep-comparing the two snapshots.
+func (m *StateManager) Snapshot() (*TradingState, int64) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	return m.state, m.version
+}
+
+// Version returns the current state version, bumped every time a
+// mutator publishes a replacement TradingState.
+func (m *StateManager) Version() int64 {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	return m.version
+}
+
+// Restore replaces the in-memory state, migrating it to
+// CurrentSchemaVersion first. Save must be called afterwards to persist it.
+func (m *StateManager) Restore(restored *TradingState) error {
+	if err := migrate(restored); err != nil {
+		return err
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.state = restored
+	m.version++
+	return nil
+}
+
+// migrate runs every migration registered for a version older than
+// state's current version, in order, bumping SchemaVersion as it goes.
+func migrate(state *TradingState) error {
+	for state.SchemaVersion < CurrentSchemaVersion {
+		migration, ok := migrations[state.SchemaVersion]
+		if !ok {
+			break
+		}
+
+		if err := migration(state); err != nil {
+			return err
+		}
+
+		state.SchemaVersion++
+	}
+
+	return nil
+}
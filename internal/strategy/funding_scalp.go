@@ -0,0 +1,61 @@
+package strategy
+
+import "math"
+
+// FundingWindowScalp trades mean reversion around an extreme funding
+// print: Binance pays funding from the side paying to the side
+// receiving, so a rate far beyond its usual baseline means one side is
+// crowded and likely to revert once the print cools off. It fades that
+// crowded side with a stop/target sized off the trading interval's ATR
+// rather than a fixed distance, since the strategy is meant to be held
+// only for the short window until the print normalizes, not ridden to a
+// trend target. See settings.TradingStrategyFundingWindowScalp.
+type FundingWindowScalp struct {
+	// Threshold is the absolute funding rate beyond which a print counts
+	// as extreme, e.g. 0.003 (0.3%) against Binance's typical +/-0.01%
+	// baseline. <= 0 disables the strategy entirely.
+	Threshold float64
+	// StopATRMultiple and TargetATRMultiple size the stop-loss/take-profit
+	// distance as a multiple of Input.ATR.
+	StopATRMultiple   float64
+	TargetATRMultiple float64
+}
+
+// NewFundingWindowScalp returns a FundingWindowScalp configured by
+// threshold/stopATRMultiple/targetATRMultiple, see the matching
+// settings.Settings fields consulted by the "funding_window_scalp"
+// factory in registry.go.
+func NewFundingWindowScalp(threshold, stopATRMultiple, targetATRMultiple float64) *FundingWindowScalp {
+	return &FundingWindowScalp{
+		Threshold:         threshold,
+		StopATRMultiple:   stopATRMultiple,
+		TargetATRMultiple: targetATRMultiple,
+	}
+}
+
+func (f *FundingWindowScalp) Name() string {
+	return "funding_window_scalp"
+}
+
+// Evaluate fades whichever side is currently paying funding: a positive
+// FundingRate means longs are paying shorts, so crowded longs are
+// expected to revert and this strategy shorts; a negative rate fades
+// the other way. ok is false if Threshold is disabled, |FundingRate|
+// doesn't clear it, or Input.ATR is non-positive (no distance to size a
+// stop against).
+func (f *FundingWindowScalp) Evaluate(input *Input) (*Plan, bool) {
+	if f.Threshold <= 0 || input == nil || input.ATR <= 0 || math.Abs(input.FundingRate) < f.Threshold {
+		return nil, false
+	}
+
+	side := "SHORT"
+	if input.FundingRate < 0 {
+		side = "LONG"
+	}
+
+	return &Plan{
+		Side:           side,
+		StopDistance:   input.ATR * f.StopATRMultiple,
+		TargetDistance: input.ATR * f.TargetATRMultiple,
+	}, true
+}
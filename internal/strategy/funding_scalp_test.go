@@ -0,0 +1,44 @@
+package strategy
+
+import "testing"
+
+func TestFundingWindowScalpEvaluate(t *testing.T) {
+	strat := NewFundingWindowScalp(0.003, 1, 1.5)
+
+	cases := []struct {
+		name       string
+		input      *Input
+		wantOk     bool
+		wantSide   string
+		wantStop   float64
+		wantTarget float64
+	}{
+		{"extreme positive funding fades longs", &Input{FundingRate: 0.004, ATR: 10}, true, "SHORT", 10, 15},
+		{"extreme negative funding fades shorts", &Input{FundingRate: -0.004, ATR: 10}, true, "LONG", 10, 15},
+		{"funding below threshold", &Input{FundingRate: 0.002, ATR: 10}, false, "", 0, 0},
+		{"no atr to size a stop against", &Input{FundingRate: 0.004, ATR: 0}, false, "", 0, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			plan, ok := strat.Evaluate(c.input)
+			if ok != c.wantOk {
+				t.Fatalf("Evaluate() ok = %v, want %v", ok, c.wantOk)
+			}
+			if !ok {
+				return
+			}
+			if plan.Side != c.wantSide || plan.StopDistance != c.wantStop || plan.TargetDistance != c.wantTarget {
+				t.Errorf("Evaluate() = %+v, want side=%s stop=%v target=%v", plan, c.wantSide, c.wantStop, c.wantTarget)
+			}
+		})
+	}
+}
+
+func TestFundingWindowScalpDisabled(t *testing.T) {
+	strat := NewFundingWindowScalp(0, 1, 1.5)
+
+	if _, ok := strat.Evaluate(&Input{FundingRate: 0.05, ATR: 10}); ok {
+		t.Fatal("Evaluate() with Threshold <= 0 should always return ok=false")
+	}
+}
@@ -0,0 +1,40 @@
+package strategy
+
+import (
+	"fmt"
+
+	"github.com/anvh2/futures-trading/internal/settings"
+)
+
+// Factory builds the Strategy for a settings.TradingStrategy from the
+// live settings.Settings, see Register/Build.
+type Factory func(cfg *settings.Settings) Strategy
+
+var factories = map[settings.TradingStrategy]Factory{
+	settings.TradingStrategyFundingWindowScalp: newFundingWindowScalp,
+}
+
+// Register adds (or overrides) the Factory used for strategyType, so a
+// caller can register additional strategies beyond the built-in ones.
+func Register(strategyType settings.TradingStrategy, factory Factory) {
+	factories[strategyType] = factory
+}
+
+// Build constructs the Strategy registered for strategyType using the
+// factory registered for it, configured from cfg.
+func Build(strategyType settings.TradingStrategy, cfg *settings.Settings) (Strategy, error) {
+	factory, ok := factories[strategyType]
+	if !ok {
+		return nil, fmt.Errorf("strategy: no strategy registered for %v", strategyType)
+	}
+
+	return factory(cfg), nil
+}
+
+func newFundingWindowScalp(cfg *settings.Settings) Strategy {
+	return NewFundingWindowScalp(
+		cfg.FundingScalpThresholdRate,
+		cfg.StopDistanceATRMultipleFor(settings.TradingStrategyFundingWindowScalp),
+		cfg.FundingScalpTargetATRMultiple,
+	)
+}
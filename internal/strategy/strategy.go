@@ -0,0 +1,44 @@
+// Package strategy holds pluggable trading strategies that resolve a
+// position's side and risk distances from inputs the caller has already
+// gathered (current price, ATR, funding rate), independent of
+// settings.Settings or the exchange SDK, the same decoupling
+// internal/risk's ComputeDecision/ValidateStops use. A Strategy is
+// looked up by settings.TradingStrategy via Build, see registry.go.
+package strategy
+
+// Strategy resolves whether a trade should be entered right now and, if
+// so, how far to place its stop-loss/take-profit.
+type Strategy interface {
+	// Name identifies the strategy for logging.
+	Name() string
+	// Evaluate resolves input into a Plan, or returns ok=false if input
+	// doesn't meet this strategy's entry condition.
+	Evaluate(input *Input) (plan *Plan, ok bool)
+}
+
+// Input is everything a Strategy needs to decide whether to enter and,
+// if so, where to place it.
+type Input struct {
+	Symbol string
+	// Entry is the current mark/last price a position would open at.
+	Entry float64
+	// ATR is the trading interval's Average True Range, see
+	// models.Oscillator.ATR.
+	ATR float64
+	// FundingRate is the symbol's current funding rate, as reported by
+	// binance.Client.GetPremiumIndex's LastFundingRate.
+	FundingRate float64
+}
+
+// Plan is a Strategy's resolved entry: which side to take and how far
+// away to place its stop-loss/take-profit, in price distance rather
+// than absolute price so the caller can apply it against whatever Entry
+// it priced the order at.
+type Plan struct {
+	// Side is "LONG" or "SHORT", see helpers.ResolvePositionSide.
+	Side string
+	// StopDistance and TargetDistance are how far the stop-loss and
+	// take-profit sit from Entry, always positive.
+	StopDistance   float64
+	TargetDistance float64
+}
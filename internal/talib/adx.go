@@ -0,0 +1,59 @@
+package talib
+
+// ADX computes the Average Directional Index over the given period,
+// using Wilder's smoothing of the directional movement indicators. It
+// measures trend strength irrespective of direction: high values mean a
+// strong trend (up or down), low values mean a ranging market.
+func ADX(period int, high, low, closing []float64) []float64 {
+	checkSameSize(high, low, closing)
+
+	plusDM := make([]float64, len(closing))
+	minusDM := make([]float64, len(closing))
+	trueRange := make([]float64, len(closing))
+
+	for i := range closing {
+		if i == 0 {
+			trueRange[i] = high[i] - low[i]
+			continue
+		}
+
+		upMove := high[i] - high[i-1]
+		downMove := low[i-1] - low[i]
+
+		if upMove > downMove && upMove > 0 {
+			plusDM[i] = upMove
+		}
+		if downMove > upMove && downMove > 0 {
+			minusDM[i] = downMove
+		}
+
+		highLow := high[i] - low[i]
+		highClose := abs(high[i] - closing[i-1])
+		lowClose := abs(low[i] - closing[i-1])
+		trueRange[i] = max3(highLow, highClose, lowClose)
+	}
+
+	smoothedTR := Rma(period, trueRange)
+	smoothedPlusDM := Rma(period, plusDM)
+	smoothedMinusDM := Rma(period, minusDM)
+
+	dx := make([]float64, len(closing))
+
+	for i := range closing {
+		if smoothedTR[i] == 0 {
+			continue
+		}
+
+		plusDI := 100 * smoothedPlusDM[i] / smoothedTR[i]
+		minusDI := 100 * smoothedMinusDM[i] / smoothedTR[i]
+
+		sum := plusDI + minusDI
+		if sum == 0 {
+			continue
+		}
+
+		dx[i] = 100 * abs(plusDI-minusDI) / sum
+	}
+
+	return Rma(period, dx)
+}
@@ -0,0 +1,42 @@
+package talib
+
+// ATR computes the Average True Range over the given period using Wilder's
+// rolling moving average of the true range.
+func ATR(period int, high, low, closing []float64) []float64 {
+	checkSameSize(high, low, closing)
+
+	trueRange := make([]float64, len(closing))
+
+	for i := range closing {
+		if i == 0 {
+			trueRange[i] = high[i] - low[i]
+			continue
+		}
+
+		highLow := high[i] - low[i]
+		highClose := abs(high[i] - closing[i-1])
+		lowClose := abs(low[i] - closing[i-1])
+
+		trueRange[i] = max3(highLow, highClose, lowClose)
+	}
+
+	return Rma(period, trueRange)
+}
+
+func abs(value float64) float64 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func max3(a, b, c float64) float64 {
+	m := a
+	if b > m {
+		m = b
+	}
+	if c > m {
+		m = c
+	}
+	return m
+}
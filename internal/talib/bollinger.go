@@ -0,0 +1,73 @@
+package talib
+
+import "math"
+
+// BollingerBandWidth computes the normalized Bollinger Band width over
+// the given period and standard deviation multiplier: (upper - lower) /
+// middle. A wide band signals an expanding/volatile market, a narrow
+// band signals a tight, ranging one.
+func BollingerBandWidth(period int, deviation float64, closing []float64) []float64 {
+	middle := sma(period, closing)
+	width := make([]float64, len(closing))
+
+	for i := range closing {
+		stddev := stdDev(period, i, closing, middle[i])
+
+		upper := middle[i] + deviation*stddev
+		lower := middle[i] - deviation*stddev
+
+		if middle[i] == 0 {
+			continue
+		}
+
+		width[i] = (upper - lower) / middle[i]
+	}
+
+	return width
+}
+
+// sma is the simple moving average over the given period.
+func sma(period int, values []float64) []float64 {
+	result := make([]float64, len(values))
+	sum := float64(0)
+
+	for i, value := range values {
+		sum += value
+
+		windowStart := i - period + 1
+		if windowStart > 0 {
+			sum -= values[windowStart-1]
+		} else {
+			windowStart = 0
+		}
+
+		count := i - windowStart + 1
+		result[i] = sum / float64(count)
+	}
+
+	return result
+}
+
+// stdDev is the population standard deviation of values over the period
+// ending at index i, around the already-computed mean.
+func stdDev(period, i int, values []float64, mean float64) float64 {
+	windowStart := i - period + 1
+	if windowStart < 0 {
+		windowStart = 0
+	}
+
+	sumSquares := float64(0)
+	count := 0
+
+	for j := windowStart; j <= i; j++ {
+		diff := values[j] - mean
+		sumSquares += diff * diff
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return math.Sqrt(sumSquares / float64(count))
+}
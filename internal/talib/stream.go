@@ -0,0 +1,128 @@
+package talib
+
+// rmaState is Rma's incremental form: Update folds in exactly one new
+// value and returns the updated moving average in O(1), instead of
+// replaying every prior value through Rma on each call.
+type rmaState struct {
+	period int
+	count  int
+	sum    float64
+	avg    float64
+}
+
+func newRmaState(period int) rmaState {
+	return rmaState{period: period}
+}
+
+func (s *rmaState) update(value float64) float64 {
+	s.count++
+
+	if s.count <= s.period {
+		s.sum += value
+		s.avg = s.sum / float64(s.count)
+	} else {
+		s.avg = ((s.avg * float64(s.period-1)) + value) / float64(s.period)
+	}
+
+	return s.avg
+}
+
+// RSIState is RSIPeriod's incremental form, tracked per symbol+interval:
+// Update folds in exactly the latest close and returns the updated RSI
+// in O(1), instead of replaying the full close history through Rma on
+// every candle.
+type RSIState struct {
+	prevClose float64
+	seeded    bool
+	gains     rmaState
+	losses    rmaState
+}
+
+// NewRSIState returns an RSI stream seeded with no prior close, mirroring
+// RSIPeriod's period-long warmup.
+func NewRSIState(period int) *RSIState {
+	return &RSIState{
+		gains:  newRmaState(period),
+		losses: newRmaState(period),
+	}
+}
+
+// Update folds close into the running gain/loss averages and returns
+// the updated RSI.
+func (s *RSIState) Update(close float64) float64 {
+	var gain, loss float64
+
+	if s.seeded {
+		difference := close - s.prevClose
+		if difference > 0 {
+			gain = difference
+		} else {
+			loss = -difference
+		}
+	}
+
+	s.prevClose = close
+	s.seeded = true
+
+	avgGain := s.gains.update(gain)
+	avgLoss := s.losses.update(loss)
+	rs := avgGain / avgLoss
+
+	return 100 - (100 / (1 + rs))
+}
+
+// KDJState is KDJ's incremental form, tracked per symbol+interval:
+// Update folds in exactly the latest candle and returns the updated
+// K/D/J in O(rPeriod) instead of replaying the full high/low/close
+// history through Max/Min/Rma on every candle.
+type KDJState struct {
+	rPeriod int
+	highs   []float64
+	lows    []float64
+	idx     int
+	filled  int
+	k       rmaState
+	d       rmaState
+}
+
+// NewKDJState returns a KDJ stream tracking the last rPeriod highs and
+// lows for its rolling high/low window.
+func NewKDJState(rPeriod, kPeriod, dPeriod int) *KDJState {
+	return &KDJState{
+		rPeriod: rPeriod,
+		highs:   make([]float64, rPeriod),
+		lows:    make([]float64, rPeriod),
+		k:       newRmaState(kPeriod),
+		d:       newRmaState(dPeriod),
+	}
+}
+
+// Update folds the latest candle into the rolling high/low window and
+// returns the updated K, D and J.
+func (s *KDJState) Update(high, low, close float64) (k, d, j float64) {
+	s.highs[s.idx%s.rPeriod] = high
+	s.lows[s.idx%s.rPeriod] = low
+	s.idx++
+
+	if s.filled < s.rPeriod {
+		s.filled++
+	}
+
+	highest, lowest := s.highs[0], s.lows[0]
+	for i := 1; i < s.filled; i++ {
+		if s.highs[i] > highest {
+			highest = s.highs[i]
+		}
+		if s.lows[i] < lowest {
+			lowest = s.lows[i]
+		}
+	}
+
+	rsv := (close - lowest) / (highest - lowest) * 100
+
+	k = s.k.update(rsv)
+	d = s.d.update(k)
+	j = (3 * k) - (2 * d)
+
+	return k, d, j
+}